@@ -25,5 +25,11 @@ func (v *Validator) Permit(key string, allowed []string) {
 			return
 		}
 	}
-	v.Check(false, key, v.T.NotPermitted(v.Scopes, allowed))
+	v.CheckCode(
+		false,
+		key,
+		CodeNotPermitted,
+		Params{"allowed": allowed},
+		v.T.NotPermitted(v.Scopes, allowed),
+	)
 }
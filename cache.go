@@ -0,0 +1,217 @@
+package validator
+
+import (
+	"container/list"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long Config.Cache keeps a result when
+// Config.CacheTTL isn't set.
+const DefaultCacheTTL = 5 * time.Minute
+
+// Cache shares the results of the database checks of Config.CachedTables
+// between requests, such as countries or currencies, see MemoryCache.
+type Cache interface {
+	Get(key string) (any, bool)
+	Set(key string, value any, ttl time.Duration)
+}
+
+// DefaultCacheSize is the number of entries a MemoryCache holds when
+// NewMemoryCache is given no size.
+const DefaultCacheSize = 10000
+
+// MemoryCache is an in-memory Cache holding at most size entries, the least
+// recently used one is evicted to make room. Entries expire after their ttl
+// and are dropped when read or when they reach the back of the cache, it's
+// safe for use by multiple goroutines.
+type MemoryCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	// order holds the entries from the most to the least recently used
+	order *list.List
+}
+
+type cacheEntry struct {
+	key     string
+	value   any
+	expires time.Time
+}
+
+var _ Cache = (*MemoryCache)(nil)
+
+// NewMemoryCache returns an empty MemoryCache of size entries, or of
+// DefaultCacheSize when size isn't positive.
+func NewMemoryCache(size int) *MemoryCache {
+	if size <= 0 {
+		size = DefaultCacheSize
+	}
+	return &MemoryCache{
+		size:    size,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+func (c *MemoryCache) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, found := c.entries[key]
+	if !found {
+		return nil, false
+	}
+	entry := el.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		c.remove(el)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return entry.value, true
+}
+
+func (c *MemoryCache) Set(key string, value any, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if el, found := c.entries[key]; found {
+		entry := el.Value.(*cacheEntry)
+		entry.value, entry.expires = value, now.Add(ttl)
+		c.order.MoveToFront(el)
+	} else {
+		c.entries[key] = c.order.PushFront(&cacheEntry{
+			key:     key,
+			value:   value,
+			expires: now.Add(ttl),
+		})
+	}
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+	// entries left unread since they expired gather at the back
+	for el := c.order.Back(); el != nil; el = c.order.Back() {
+		if !now.After(el.Value.(*cacheEntry).expires) {
+			break
+		}
+		c.remove(el)
+	}
+}
+
+// Len returns the number of entries held, expired ones included until they
+// are dropped.
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *MemoryCache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*cacheEntry).key)
+}
+
+// cached runs query into dest unless its result is known, either from an
+// earlier identical query of the request or, for tables listed in
+// Config.CachedTables, from Config.Cache. table is empty for queries that
+// aren't shared. run reports whether its result may be shared: a row that
+// wasn't found may be inserted by the next request, so only the request
+// remembers it.
+func (v *Validator) cached(
+	table string,
+	dest any,
+	query string,
+	args []any,
+	run func() (share bool, err error),
+) error {
+	key := cacheKey(dest, query, args)
+	if val, found := v.recall(key); found {
+		setDest(dest, val)
+		return nil
+	}
+	_, shared := v.cachedTables[table]
+	shared = shared && v.cache != nil
+	if shared {
+		if val, found := v.cache.Get(key); found {
+			setDest(dest, val)
+			v.remember(key, val)
+			return nil
+		}
+	}
+	share, err := run()
+	if err != nil {
+		return err
+	}
+	val := reflect.ValueOf(dest).Elem().Interface()
+	v.remember(key, val)
+	if shared && share {
+		ttl := v.cacheTTL
+		if ttl <= 0 {
+			ttl = DefaultCacheTTL
+		}
+		v.cache.Set(key, val, ttl)
+	}
+	return nil
+}
+
+func (v *Validator) recall(key string) (any, bool) {
+	if v.memo == nil {
+		return nil, false
+	}
+	return v.memo.get(key)
+}
+
+func (v *Validator) remember(key string, val any) {
+	if v.memo != nil {
+		v.memo.set(key, val)
+	}
+}
+
+// found reports whether dest holds a row rather than its zero value, or
+// true for a boolean such as the result of EXISTS.
+func found(dest any) bool {
+	rv := reflect.ValueOf(dest).Elem()
+	if rv.Kind() == reflect.Bool {
+		return rv.Bool()
+	}
+	return !rv.IsZero()
+}
+
+func setDest(dest, val any) {
+	reflect.ValueOf(dest).Elem().Set(reflect.ValueOf(val))
+}
+
+// cacheKey identifies a query by its text, its arguments and the type it's
+// read into, pointers are dereferenced so equal values share a key.
+func cacheKey(dest any, query string, args []any) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%T\x00%s", dest, query)
+	for _, arg := range args {
+		b.WriteByte(0)
+		b.WriteString(cacheArg(arg))
+	}
+	return b.String()
+}
+
+func cacheArg(arg any) string {
+	rv := reflect.ValueOf(arg)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return "NULL"
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return "NULL"
+	}
+	val := rv.Interface()
+	if valuer, ok := val.(driver.Valuer); ok {
+		if dv, err := valuer.Value(); err == nil {
+			val = dv
+		}
+	}
+	return fmt.Sprintf("%T:%v", val, val)
+}
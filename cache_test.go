@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache(t *testing.T) {
+	c := NewMemoryCache(2)
+	c.Set("a", 1, time.Minute)
+	c.Set("b", 2, time.Minute)
+	c.Get("a")
+	c.Set("c", 3, time.Minute)
+	if _, found := c.Get("b"); found {
+		t.Error("the least recently used entry wasn't evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, found := c.Get(key); !found {
+			t.Errorf("%s was evicted", key)
+		}
+	}
+
+	c = NewMemoryCache(0)
+	c.Set("old", 1, -time.Second)
+	if _, found := c.Get("old"); found {
+		t.Error("an expired entry was returned")
+	}
+	c.Set("stale", 1, -time.Second)
+	c.Set("fresh", 2, time.Minute)
+	if c.Len() != 1 {
+		t.Errorf("Len() = %d, the expired entry wasn't dropped", c.Len())
+	}
+}
+
+func TestCacheShared(t *testing.T) {
+	tests := []struct {
+		name    string
+		exists  bool
+		check   func(v *Validator)
+		queries int
+	}{
+		{
+			name:   "found",
+			exists: true,
+			check: func(v *Validator) {
+				v.Exists(1, "country_id", "id", "countries", true)
+			},
+			queries: 1,
+		},
+		{
+			name:   "missing",
+			exists: false,
+			check: func(v *Validator) {
+				v.Exists(1, "country_id", "id", "countries", true)
+			},
+			queries: 2,
+		},
+		{
+			name:   "unique",
+			exists: true,
+			check: func(v *Validator) {
+				v.Unique("name", "countries", "x", UniqueConfig{})
+			},
+			queries: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries := 0
+			get := func(_ context.Context, dest any, _ string, _ ...any) error {
+				queries++
+				*dest.(*bool) = tt.exists
+				return nil
+			}
+			conn := &stubConn{get: get}
+			cache := NewMemoryCache(0)
+			// two requests, each running the check twice
+			for range 2 {
+				v, err := newTestValidator(
+					t,
+					&Config{
+						Conn:         conn,
+						Cache:        cache,
+						CachedTables: []string{"countries"},
+					},
+					"application/json",
+					`{}`,
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+				tt.check(v)
+				tt.check(v)
+			}
+			if queries != tt.queries {
+				t.Fatalf("%d queries, want %d", queries, tt.queries)
+			}
+		})
+	}
+}
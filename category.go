@@ -1,9 +1,9 @@
 package validator
 
 import (
-	"context"
 	"fmt"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 )
 
@@ -14,80 +14,74 @@ func (v *Validator) CategoryValidator(
 	id *uuid.UUID,
 	fieldName, superParentId string,
 ) {
-	var exists bool
-	query := `
-        SELECT EXISTS(
-            SELECT 1 
-              FROM Categories 
-             WHERE id=$1
-               AND super_parent_id=$2
-        ) AS exists
-    `
-	if err := v.Conn.GetContext(
-		context.Background(),
-		&exists,
-		query,
-		id,
-		superParentId,
-	); err != nil {
-		exists = false
-	}
-	if !exists {
-		v.Check(exists, fieldName, v.T.ValidateCategoryInput())
+	where := []squirrel.Sqlizer{
+		// Expr rather than Eq, which panics calling Value on a nil id
+		squirrel.Expr("id = ?", snapshot(id)),
+		squirrel.Eq{"super_parent_id": superParentId},
 	}
+	v.run(func(v *Validator) {
+		exists, ok := v.existsWhere(fieldName, "categories", true, where...)
+		if ok && !exists {
+			v.CheckCode(
+				exists,
+				fieldName,
+				CodeCategory,
+				nil,
+				v.T.ValidateCategoryInput(),
+			)
+		}
+	})
 }
 
+// ValidateCategoryArray checks that every element of fieldName is a category
+// of superParentID, with a single query.
 func (v *Validator) ValidateCategoryArray(
 	fieldName, superParentID string,
 	required bool,
 ) *[]string {
-	arr, ok := v.Data.Values[fieldName]
+	arr, ok := v.Data.GetStrings(fieldName), v.Data.KeyExists(fieldName)
 	if required && !ok {
-		v.Check(false, fieldName, v.T.ValidateRequiredArray())
+		v.CheckCode(
+			false,
+			fieldName,
+			CodeRequiredArray,
+			nil,
+			v.T.ValidateRequiredArray(),
+		)
 	}
 	if required && len(arr) == 0 {
-		v.Check(
+		v.CheckCode(
 			false,
 			fmt.Sprintf("%s.0", fieldName),
+			CodeUUID,
+			nil,
 			v.T.ValidateUUID(),
 		)
 	}
 	if ok && len(arr) > 0 {
-		for index, id := range arr {
-			if _, err := uuid.Parse(id); err != nil {
-				v.Check(
-					false,
-					fmt.Sprintf("%s.%d", fieldName, index),
-					v.T.ValidateUUID(),
-				)
-			} else {
-				var exists bool
-				query := `
-                    SELECT EXISTS(
-                        SELECT 1 
-                        FROM categories 
-                        WHERE id=$1 
-                          AND super_parent_id=$2
-                    )
-                `
-				if err := v.Conn.GetContext(
-					context.Background(),
-					&exists,
-					query,
-					id,
-					superParentID,
-				); err != nil {
-					exists = false
-				}
-				if required && !exists {
-					v.Check(
+		ids := v.listUUIDs(fieldName, arr)
+		v.run(func(v *Validator) {
+			found, err := v.foundIDs(
+				"categories",
+				ids,
+				squirrel.Eq{"super_parent_id": superParentID},
+			)
+			if err != nil {
+				v.queryFailed(fieldName, err)
+				return
+			}
+			for _, id := range ids {
+				if _, exists := found[id.id]; required && !exists {
+					v.CheckCode(
 						exists,
-						fmt.Sprintf("%s.%d", fieldName, index),
+						fmt.Sprintf("%s.%d", fieldName, id.index),
+						CodeCategory,
+						nil,
 						v.T.ValidateCategoryInput(),
 					)
 				}
 			}
-		}
+		})
 	}
 	return &arr
 }
@@ -0,0 +1,198 @@
+package validator
+
+import (
+	"strings"
+
+	"github.com/m-row/validator/interfaces"
+
+	js "github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// Codes identify each kind of validation failure, unlike messages they don't
+// depend on the translation so clients can rely on them.
+const (
+	CodeInvalid           = "invalid"
+	CodeRequired          = "required"
+	CodeRequiredArray     = "required_array"
+	CodeMinLength         = "min_length"
+	CodeMaxLength         = "max_length"
+	CodeMin               = "min"
+	CodeMax               = "max"
+	CodeInt               = "int"
+	CodeFloat             = "float"
+	CodeUUID              = "uuid"
+	CodeDate              = "date"
+	CodeJSON              = "json"
+	CodeNotExists         = "not_exists"
+	CodeAlreadyExists     = "already_exists"
+	CodeNotPermitted      = "not_permitted"
+	CodeMustHaveRole      = "must_have_role"
+	CodeCategory          = "category"
+	CodeStartWithLetter   = "start_with_letter"
+	CodeAlphanumeric      = "alphanumeric_dash_underscore"
+	CodeNotImage          = "not_image"
+	CodeFileExtension     = "file_extension"
+	CodeMinFiles          = "min_files"
+	CodeMaxFiles          = "max_files"
+	CodeFileSize          = "file_size"
+	CodePhone             = "phone"
+	CodeRegion            = "region"
+	CodeCountryCode       = "country_code"
+	CodeCSVHeader         = "csv_header"
+	CodeUnknownKey        = "unknown_key"
+	CodeUnsupportedSource = "unsupported_source"
+	CodeSchema            = "schema"
+	CodeEnum              = "enum"
+	CodeFormat            = "format"
+	CodeType              = "type"
+	CodePattern           = "pattern"
+	// CodeDuplicate is reported at field.N for a list element repeating an
+	// earlier one, see NoDuplicates.
+	CodeDuplicate = "duplicate"
+	// CodeCanceled and CodeTimeout are the message codes of the problem
+	// reported when a database check couldn't complete because the request
+	// was canceled or its deadline, or Config.QueryTimeout, was exceeded.
+	CodeCanceled = "canceled"
+	CodeTimeout  = "timeout"
+	// CodeBodyTooLarge and CodeUnsupportedMediaType are the message codes of
+	// the problem reported for a request that couldn't be parsed, see
+	// NewProblem.
+	CodeBodyTooLarge         = "body_too_large"
+	CodeUnsupportedMediaType = "unsupported_media_type"
+)
+
+// Params holds the values a message was built with, such as {"min": 3}.
+type Params map[string]any
+
+// ErrorDetail is a validation failure with its code and params next to the
+// translated message.
+type ErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Params  Params `json:"params,omitempty"`
+}
+
+// ErrorDetails maps keys to their failures, it's keyed the same way as
+// Errors.
+type ErrorDetails map[string][]ErrorDetail
+
+// CheckCode the boolean condition, if !ok an error will be added to the
+// causes with its code and params:
+//
+//	v.CheckCode(
+//		len(tags) <= 5,
+//		"tags",
+//		validator.CodeMax,
+//		validator.Params{"max": 5},
+//		v.T.ValidateMustBeLteValue(5),
+//	)
+func (v *Validator) CheckCode(
+	ok bool,
+	instanceLocation, code string,
+	params Params,
+	message string,
+) {
+	if !ok {
+		v.addCause(&js.ValidationError{
+			InstanceLocation: instanceLocation,
+			Message:          message,
+			Causes:           []*js.ValidationError{},
+		}, code, params)
+	}
+}
+
+// addCause adds cause recording its code and params.
+func (v *Validator) addCause(
+	cause *js.ValidationError,
+	code string,
+	params Params,
+) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.Error.Causes = append(v.Error.Causes, cause)
+	if v.details == nil {
+		v.details = map[*js.ValidationError]ErrorDetail{}
+	}
+	v.details[cause] = ErrorDetail{
+		Code:    code,
+		Message: cause.Message,
+		Params:  params,
+	}
+}
+
+// GetErrorDetails returns the same failures as GetErrorMap with their codes
+// and params. Causes added without a code, such as json schema failures, are
+// reported with the schema keyword that failed or CodeInvalid.
+func (v *Validator) GetErrorDetails() ErrorDetails {
+	v.runJobs(nil)
+	details := ErrorDetails{}
+	v.loopCauseDetails(details, v.Error.Causes)
+	return details
+}
+
+func (v *Validator) loopCauseDetails(
+	details ErrorDetails,
+	causes []*js.ValidationError,
+) {
+	for _, cause := range causes {
+		if len(cause.Causes) != 0 {
+			v.loopCauseDetails(details, cause.Causes)
+			continue
+		}
+		key := errorKey(cause.InstanceLocation)
+		details[key] = append(details[key], v.causeDetail(cause))
+	}
+}
+
+// Message returns the message of code when T implements
+// interfaces.MessageTranslation and has one, fallback otherwise. It lets a
+// validator use a message that has no Translation method:
+//
+//	v.CheckCode(
+//		ok,
+//		"slug",
+//		"slug",
+//		nil,
+//		v.Message("slug", nil, "must be a valid slug"),
+//	)
+func (v *Validator) Message(
+	code string,
+	params Params,
+	fallback string,
+) string {
+	return message(v.T, code, params, fallback)
+}
+
+// message returns the message of code in t, or fallback when t has none.
+func message(
+	t interfaces.Translation,
+	code string,
+	params Params,
+	fallback string,
+) string {
+	if mt, ok := t.(interfaces.MessageTranslation); ok {
+		if msg, found := mt.Message(code, params); found {
+			return msg
+		}
+	}
+	return fallback
+}
+
+// causeDetail returns the detail recorded for cause by CheckCode, or one
+// built from its keyword location.
+func (v *Validator) causeDetail(cause *js.ValidationError) ErrorDetail {
+	if detail, found := v.details[cause]; found {
+		return detail
+	}
+	code := CodeInvalid
+	if i := strings.LastIndexByte(cause.KeywordLocation, '/'); i >= 0 {
+		code = cause.KeywordLocation[i+1:]
+	}
+	return ErrorDetail{Code: code, Message: cause.Message}
+}
+
+// errorKey converts an instance location to the dotted key of the error maps.
+func errorKey(instanceLocation string) string {
+	key := strings.Replace(instanceLocation, "/", "", 1)
+	return strings.ReplaceAll(key, "/", ".")
+}
@@ -1,10 +1,12 @@
 package validator
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
 	"path"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/m-row/finder"
@@ -21,6 +23,63 @@ type Config struct {
 	Schema  *js.Schema
 	RootDIR string
 	DOMAIN  string
+
+	// Sources lists, in order of precedence, where Data is read from,
+	// defaults to DefaultSources.
+	Sources []Source
+	// PathValues, Headers and Cookies name the path parameters, headers
+	// and cookies read into Data when their source is listed in Sources.
+	PathValues []string
+	Headers    []string
+	Cookies    []string
+
+	// Strict rejects every key of the request that wasn't assigned, see
+	// Validator.RejectUnknownKeys. AllowedKeys are never rejected, such as
+	// pagination parameters from the query string.
+	Strict      bool
+	AllowedKeys []string
+
+	// MaxBodySize limits the request body as sent, defaults to
+	// DefaultMaxBodySize, or DefaultMaxFormBodySize for urlencoded forms.
+	// Multipart bodies aren't limited by default and a negative value
+	// means no limit. MaxDecodedBodySize limits a compressed body once
+	// decompressed, defaults to DefaultMaxDecodedBodySize. Exceeding either
+	// results in a *BodyTooLargeError.
+	MaxBodySize        int64
+	MaxDecodedBodySize int64
+
+	// DefaultLocale is the locale used when T is nil and the request
+	// matches no registered translation, defaults to DefaultLocale. See
+	// Negotiate.
+	DefaultLocale string
+
+	// Context bounds the database checks, defaults to the context of
+	// Request. QueryTimeout further limits each query, 0 means no limit.
+	Context      context.Context
+	QueryTimeout time.Duration
+
+	// Tables, when set, lists the only tables the database checks may
+	// query, a check of any other table is reported by Validator.Err.
+	Tables []string
+
+	// Cache shares the results of the checks of CachedTables between
+	// requests for CacheTTL, defaults to DefaultCacheTTL. Only rows that
+	// were found are shared, missing ones and Unique checks aren't.
+	// Results are always reused within a request.
+	Cache        Cache
+	CacheTTL     time.Duration
+	CachedTables []string
+
+	// Deferred queues the database checks until Validator.Validate runs
+	// them, Parallelism at a time, defaults to DefaultParallelism.
+	Deferred    bool
+	Parallelism int
+
+	// Dialect selects the placeholders and quoting of the queries, over
+	// the placeholders of QB. It's inferred from QB when empty, without
+	// either the queries are built for postgres. Any other value than the
+	// Dialect constants results in an *UnsupportedDialectError.
+	Dialect Dialect
 }
 
 func (v *Validator) GetRootPath(dir string) string {
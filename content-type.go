@@ -0,0 +1,43 @@
+package validator
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// UnsupportedContentTypeError is returned by ParseMax when the request body
+// is sent with a media type, charset or content encoding the parser can't
+// decode.
+type UnsupportedContentTypeError struct {
+	ContentType string
+	Charset     string
+	Encoding    string
+}
+
+func (e *UnsupportedContentTypeError) Error() string {
+	if e.Encoding != "" {
+		return "unsupported content encoding: " + e.Encoding
+	}
+	if e.Charset != "" {
+		return "unsupported charset: " + e.Charset
+	}
+	return "unsupported content type: " + e.ContentType
+}
+
+// toUTF8 converts body from charset to utf-8, an empty charset is assumed to
+// be utf-8 already.
+func toUTF8(body []byte, charset string) ([]byte, error) {
+	switch strings.ToLower(charset) {
+	case "", "utf-8", "utf8", "us-ascii", "ascii":
+		return body, nil
+	case "iso-8859-1", "latin1", "latin-1":
+		// every latin1 byte maps to the unicode code point of the same value
+		buf := make([]byte, 0, len(body))
+		for _, b := range body {
+			buf = utf8.AppendRune(buf, rune(b))
+		}
+		return buf, nil
+	default:
+		return nil, &UnsupportedContentTypeError{Charset: charset}
+	}
+}
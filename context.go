@@ -0,0 +1,62 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+)
+
+// Context returns the context of the database checks, Config.Context or the
+// context of the request.
+func (v *Validator) Context() context.Context {
+	if v.ctx == nil {
+		return context.Background()
+	}
+	return v.ctx
+}
+
+// queryContext returns the context of a single query, bounded by
+// Config.QueryTimeout when set.
+func (v *Validator) queryContext() (context.Context, context.CancelFunc) {
+	if v.queryTimeout > 0 {
+		return context.WithTimeout(v.Context(), v.queryTimeout)
+	}
+	return context.WithCancel(v.Context())
+}
+
+// get runs a query of table returning a single row into dest within
+// queryContext, see cached. A false or zero result isn't shared.
+func (v *Validator) get(
+	table string,
+	dest any,
+	query string,
+	args ...any,
+) error {
+	return v.cached(table, dest, query, args, func() (bool, error) {
+		ctx, cancel := v.queryContext()
+		defer cancel()
+		if err := v.Conn.GetContext(ctx, dest, query, args...); err != nil {
+			return false, err
+		}
+		return found(dest), nil
+	})
+}
+
+// selectRows runs a query of table returning rows into the slice dest within
+// queryContext, see cached. A result of fewer than want rows misses some and
+// isn't shared.
+func (v *Validator) selectRows(
+	table string,
+	dest any,
+	want int,
+	query string,
+	args ...any,
+) error {
+	return v.cached(table, dest, query, args, func() (bool, error) {
+		ctx, cancel := v.queryContext()
+		defer cancel()
+		if err := v.Conn.SelectContext(ctx, dest, query, args...); err != nil {
+			return false, err
+		}
+		return reflect.ValueOf(dest).Elem().Len() >= want, nil
+	})
+}
@@ -26,7 +26,7 @@ func (v *Validator) ParseCSV(
 	}
 	fileExists := v.Data.FileExists(key)
 	if !fileExists && required {
-		v.Check(false, key, v.T.ValidateRequired())
+		v.CheckCode(false, key, CodeRequired, nil, v.T.ValidateRequired())
 	}
 
 	if fileExists {
@@ -81,9 +81,15 @@ func (v *Validator) ParseCSV(
 
 		for i := range firstRow {
 			if firstRow[i] != csvHeader[i] {
-				v.Check(
+				v.CheckCode(
 					false,
 					key,
+					CodeCSVHeader,
+					Params{
+						"index":    i,
+						"expected": csvHeader[i],
+						"found":    firstRow[i],
+					},
 					fmt.Sprintf(
 						"header row[%d] should be: %s, found: %s",
 						i,
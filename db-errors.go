@@ -0,0 +1,55 @@
+package validator
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+)
+
+// DBError is a database failure met while checking key, such as a dropped
+// connection or a missing table. It's reported by Err instead of a
+// validation failure of key since the input may well be valid.
+type DBError struct {
+	Key string
+	Err error
+}
+
+func (e *DBError) Error() string {
+	return e.Key + ": " + e.Err.Error()
+}
+
+func (e *DBError) Unwrap() error {
+	return e.Err
+}
+
+// Err returns the database failures met by the checks joined together, nil
+// when there were none. A handler should answer them with a 500, 503 or 504
+// rather than the validation failures, see Problem:
+//
+//	if err := v.Err(); err != nil {
+//		return err
+//	}
+//	if !v.Valid() {
+//		return v.WriteProblem(w)
+//	}
+func (v *Validator) Err() error {
+	v.runJobs(nil)
+	return errors.Join(v.errs...)
+}
+
+// queryFailed records err, the error of the query checking key, as a
+// database failure, including a canceled or timed out query.
+func (v *Validator) queryFailed(key string, err error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.errs = append(v.errs, &DBError{Key: key, Err: err})
+}
+
+// unavailable reports whether err means the database couldn't be reached.
+func unavailable(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.As(err, &netErr)
+}
@@ -0,0 +1,127 @@
+package validator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/m-row/finder"
+)
+
+// queryFunc runs a query of a stubConn.
+type queryFunc func(ctx context.Context, dest any, q string, args ...any) error
+
+// stubConn is a finder.Connection whose queries run the given functions, the
+// other methods aren't used by the validator and panic.
+type stubConn struct {
+	finder.Connection
+	get      queryFunc
+	selectFn queryFunc
+}
+
+func (c *stubConn) GetContext(
+	ctx context.Context,
+	dest any,
+	query string,
+	args ...any,
+) error {
+	return c.get(ctx, dest, query, args...)
+}
+
+func (c *stubConn) SelectContext(
+	ctx context.Context,
+	dest any,
+	query string,
+	args ...any,
+) error {
+	return c.selectFn(ctx, dest, query, args...)
+}
+
+// blocking waits until the context of the query is done.
+func blocking(ctx context.Context, _ any, _ string, _ ...any) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestQueryInterrupted(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name   string
+		config Config
+		err    error
+		status int
+	}{
+		{
+			name: "timeout",
+			config: Config{
+				QueryTimeout: time.Millisecond,
+			},
+			err:    context.DeadlineExceeded,
+			status: http.StatusGatewayTimeout,
+		},
+		{
+			name: "canceled",
+			config: Config{
+				Context: canceled,
+			},
+			err:    context.Canceled,
+			status: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.config
+			c.Conn = &stubConn{get: blocking}
+			v, err := newTestValidator(t, &c, "application/json", `{}`)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v.ExistsWhere("id", "users", squirrel.Eq{"id": 1})
+			if !errors.Is(v.Err(), tt.err) {
+				t.Fatalf("Err() = %v, want %v", v.Err(), tt.err)
+			}
+			if v.Valid() {
+				t.Fatal("Valid() = true")
+			}
+			if len(v.GetErrorMap()) != 0 {
+				t.Fatalf("field errors %v", v.GetErrorMap())
+			}
+			p := v.Problem()
+			if p.Status != tt.status {
+				t.Fatalf("status %d, want %d", p.Status, tt.status)
+			}
+			if p.Title == v.T.InternalServerError() {
+				t.Fatalf("untranslated title %q", p.Title)
+			}
+		})
+	}
+}
+
+func TestPhoneCountryNotFound(t *testing.T) {
+	v, err := newTestValidator(
+		t,
+		&Config{Conn: &stubConn{
+			get: func(context.Context, any, string, ...any) error {
+				return sql.ErrNoRows
+			},
+		}},
+		"application/json",
+		`{"country_code":"999"}`,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v.ValidatePhone("0921234567")
+	if v.Err() != nil {
+		t.Fatalf("Err() = %v", v.Err())
+	}
+	details := v.GetErrorDetails()["country_code"]
+	if len(details) != 1 || details[0].Message != v.T.ValidateExistsInDB() {
+		t.Fatalf("details %+v", details)
+	}
+}
@@ -0,0 +1,303 @@
+package validator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-json"
+)
+
+// Decoder decodes the body of a request into data. params holds the
+// parameters of the media type (charset, boundary...), maxMemory is the
+// in-memory limit passed to ParseMax for decoders that spool to disk.
+// Decoders of structured bodies record their values with Data.AddJSON to
+// keep nulls and containers, flat ones such as forms with Data.Add.
+type Decoder func(
+	data *Data,
+	req *http.Request,
+	params map[string]string,
+	maxMemory int64,
+) error
+
+// ErrXMLRootText is returned by DecodeXML for a root element holding only
+// text, such as <name>x</name>, which has no key to be read under.
+var ErrXMLRootText = errors.New("xml root element holds text instead of fields")
+
+// NDJSONKey is the key under which DecodeNDJSON stores the records of the
+// body, each line is addressed by its index: records.0.name
+const NDJSONKey = "records"
+
+var (
+	decodersMu sync.RWMutex
+	decoders   = map[string]Decoder{
+		"multipart/form-data":               DecodeMultipart,
+		"application/x-www-form-urlencoded": DecodeForm,
+		"application/json":                  DecodeJSON,
+		"application/xml":                   DecodeXML,
+		"text/xml":                          DecodeXML,
+		"application/x-ndjson":              DecodeNDJSON,
+	}
+)
+
+// RegisterDecoder registers the decoder used by ParseMax for a media type,
+// replacing any existing one. It's safe to call from multiple goroutines but
+// is meant to be called at init:
+//
+//	validator.RegisterDecoder("application/yaml", decodeYAML)
+func RegisterDecoder(mediaType string, decoder Decoder) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+	decoders[strings.ToLower(mediaType)] = decoder
+}
+
+// lookupDecoder returns the decoder of mediaType, a structured syntax suffix
+// such as application/problem+json falls back to the decoder of
+// application/json.
+func lookupDecoder(mediaType string) Decoder {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+	if decoder, found := decoders[mediaType]; found {
+		return decoder
+	}
+	if i := strings.LastIndexByte(mediaType, '+'); i >= 0 {
+		return decoders["application/"+mediaType[i+1:]]
+	}
+	return nil
+}
+
+// DecodeMultipart decodes a multipart/form-data body, keeping every file of
+// each key.
+func DecodeMultipart(
+	data *Data,
+	req *http.Request,
+	_ map[string]string,
+	maxMemory int64,
+) error {
+	if err := req.ParseMultipartForm(maxMemory); err != nil {
+		return err
+	}
+	data.decoder = SourceMultipart
+	data.addValues(req.MultipartForm.Value)
+	for key, files := range req.MultipartForm.File {
+		for _, file := range files {
+			data.AddFile(key, file)
+		}
+	}
+	return nil
+}
+
+// DecodeForm decodes an application/x-www-form-urlencoded body.
+func DecodeForm(
+	data *Data,
+	req *http.Request,
+	params map[string]string,
+	_ int64,
+) error {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return err
+	}
+	// charset applies to the percent decoded octets, not the body
+	values := make(url.Values, len(form))
+	for key, vals := range form {
+		k, err := toUTF8([]byte(key), params["charset"])
+		if err != nil {
+			return err
+		}
+		for _, val := range vals {
+			val, err := toUTF8([]byte(val), params["charset"])
+			if err != nil {
+				return err
+			}
+			values.Add(string(k), string(val))
+		}
+	}
+	data.decoder = SourceForm
+	data.addValues(values)
+	return nil
+}
+
+// DecodeJSON decodes an application/json body holding an object, the body
+// is kept for BindJSON.
+func DecodeJSON(
+	data *Data,
+	req *http.Request,
+	params map[string]string,
+	_ int64,
+) error {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	if body, err = toUTF8(body, params["charset"]); err != nil {
+		return err
+	}
+	data.decoder = SourceJSON
+	data.jsonBody = body
+	return parseJSON(data, data.jsonBody)
+}
+
+// DecodeNDJSON decodes an application/x-ndjson body, each line is stored as
+// an element of the array at NDJSONKey. Blank lines are skipped.
+func DecodeNDJSON(
+	data *Data,
+	req *http.Request,
+	params map[string]string,
+	_ int64,
+) error {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	if body, err = toUTF8(body, params["charset"]); err != nil {
+		return err
+	}
+	data.decoder = SourceJSON
+	records := []any{}
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64<<10), len(body)+1)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var record any
+		dec := json.NewDecoder(bytes.NewReader(scanner.Bytes()))
+		dec.UseNumber()
+		if err := dec.Decode(&record); err != nil {
+			return fmt.Errorf("ndjson line %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return data.AddJSON(NDJSONKey, records)
+}
+
+// DecodeXML decodes an application/xml or text/xml body. The children of the
+// root element become the keys of Data:
+//
+//	<order>
+//	  <customer id="7"><name>x</name></customer>
+//	  <item><qty>1</qty></item>
+//	  <item><qty>2</qty></item>
+//	  <note xsi:nil="true"/>
+//	</order>
+//
+// is read as customer.@id, customer.name, item.0.qty, item.1.qty and a null
+// note. Repeated elements become arrays, attributes are prefixed with @ and
+// the text of an element with attributes or children is kept under #text:
+// <price currency="LYD">10</price> is read as price.@currency and
+// price.#text.
+//
+// XML can't tell a single element apart from an array of one, so an element
+// sent once isn't indexed: a lone <item> is read as item.qty, not
+// item.0.qty. A root element holding only text results in ErrXMLRootText.
+func DecodeXML(
+	data *Data,
+	req *http.Request,
+	params map[string]string,
+	_ int64,
+) error {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	if body, err = toUTF8(body, params["charset"]); err != nil {
+		return err
+	}
+	dec := xml.NewDecoder(bytes.NewReader(body))
+	// the body is utf-8 by now, whatever the xml declaration says
+	dec.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			root, err := decodeXMLElement(dec, start)
+			if err != nil {
+				return err
+			}
+			switch root := root.(type) {
+			case map[string]any:
+				for key, val := range root {
+					if err := data.AddJSON(key, val); err != nil {
+						return err
+					}
+				}
+			case string:
+				if root != "" {
+					return ErrXMLRootText
+				}
+			}
+			return nil
+		}
+	}
+}
+
+// decodeXMLElement decodes the element opened by start into its text, or a
+// map of its attributes and children when it has any.
+func decodeXMLElement(dec *xml.Decoder, start xml.StartElement) (any, error) {
+	m := map[string]any{}
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "nil" && attr.Value == "true" {
+			return nil, dec.Skip()
+		}
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		m["@"+attr.Name.Local] = attr.Value
+	}
+	var text strings.Builder
+	children := map[string][]any{}
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			child, err := decodeXMLElement(dec, tok)
+			if err != nil {
+				return nil, err
+			}
+			children[tok.Name.Local] = append(children[tok.Name.Local], child)
+		case xml.CharData:
+			text.Write(tok)
+		case xml.EndElement:
+			trimmed := strings.TrimSpace(text.String())
+			if len(children) == 0 && len(m) == 0 {
+				return trimmed, nil
+			}
+			if trimmed != "" {
+				m["#text"] = trimmed
+			}
+			for name, elements := range children {
+				if len(elements) == 1 {
+					m[name] = elements[0]
+				} else {
+					m[name] = elements
+				}
+			}
+			return m, nil
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package validator
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"testing"
+)
+
+func TestDecodeXML(t *testing.T) {
+	body := `<?xml version="1.0"?>
+<order xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+	<customer id="7"><name>ali</name></customer>
+	<price currency="LYD">10</price>
+	<item><qty>1</qty></item>
+	<item><qty>2</qty></item>
+	<box><size>3</size></box>
+	<note xsi:nil="true"/>
+</order>`
+	v, err := newTestValidator(t, nil, "application/xml", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "customer.@id", want: "7"},
+		{key: "customer.name", want: "ali"},
+		{key: "price.@currency", want: "LYD"},
+		{key: "price.#text", want: "10"},
+		{key: "item.0.qty", want: "1"},
+		{key: "item.1.qty", want: "2"},
+		{key: "box.size", want: "3"},
+		{key: "box.0.size", want: ""},
+	}
+	for _, tt := range tests {
+		if got := v.Data.Get(tt.key); got != tt.want {
+			t.Errorf("Get(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+	if got := v.Data.Len("item"); got != 2 {
+		t.Errorf("Len(item) = %d, want 2", got)
+	}
+	if !v.Data.IsNull("note") {
+		t.Error("note isn't null")
+	}
+}
+
+func TestDecodeXMLRoot(t *testing.T) {
+	tests := []struct {
+		body string
+		err  error
+	}{
+		{body: "<name>x</name>", err: ErrXMLRootText},
+		{body: "<name> </name>"},
+		{body: "<order/>"},
+		{body: `<order xmlns:xsi="x" xsi:nil="true"/>`},
+	}
+	for _, tt := range tests {
+		v, err := newTestValidator(t, nil, "application/xml", tt.body)
+		if !errors.Is(err, tt.err) {
+			t.Fatalf("%s: got %v, want %v", tt.body, err, tt.err)
+		}
+		if err == nil && len(v.Data.Values) != 0 {
+			t.Fatalf("%s: values %v", tt.body, v.Data.Values)
+		}
+	}
+}
+
+func TestDecodeMultipart(t *testing.T) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	fields := [][2]string{
+		{"name", "ali"},
+		{"tags[]", "c"},
+		{"tags[0]", "a"},
+		{"tags[1]", "b"},
+	}
+	for _, field := range fields {
+		if err := w.WriteField(field[0], field[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"a.txt", "b.txt"} {
+		f, err := w.CreateFormFile("files[]", name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	v, err := newTestValidator(
+		t,
+		nil,
+		w.FormDataContentType(),
+		body.String(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]string{
+		"name":   "ali",
+		"tags.0": "a",
+		"tags.1": "b",
+		"tags.2": "c",
+	} {
+		if got := v.Data.Get(key); got != want {
+			t.Errorf("Get(%q) = %q, want %q", key, got, want)
+		}
+	}
+	if got := len(v.Data.GetFiles("files")); got != 2 {
+		t.Errorf("%d files, want 2", got)
+	}
+}
+
+func TestRegisterDecoderAddJSON(t *testing.T) {
+	RegisterDecoder(
+		"application/x-test",
+		func(data *Data, _ *http.Request, _ map[string]string, _ int64) error {
+			if err := data.AddJSON("note", nil); err != nil {
+				return err
+			}
+			return data.AddJSON("tags", []any{"a", ""})
+		},
+	)
+	v, err := newTestValidator(t, nil, "application/x-test", "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.Data.IsNull("note") {
+		t.Error("note isn't null")
+	}
+	if got := v.Data.Len("tags"); got != 2 {
+		t.Errorf("Len(tags) = %d, want 2", got)
+	}
+	if _, ok := v.Data.assigned("tags.1"); !ok {
+		t.Error("empty string isn't assigned")
+	}
+}
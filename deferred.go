@@ -0,0 +1,150 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	js "github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// DefaultParallelism is the number of deferred checks Validate runs at once
+// when Config.Parallelism isn't set.
+const DefaultParallelism = 4
+
+// queryMemo holds the results of the queries of a request by cacheKey, it's
+// shared by the deferred checks.
+type queryMemo struct {
+	mu      sync.Mutex
+	results map[string]any
+}
+
+func (m *queryMemo) get(key string) (any, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	val, found := m.results[key]
+	return val, found
+}
+
+func (m *queryMemo) set(key string, val any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.results[key] = val
+}
+
+// run runs the database part of a check, or queues it until Validate when
+// Config.Deferred is set. job reports to the validator it's given, which
+// has no Data in deferred mode: the values a job needs are read before it's
+// queued.
+func (v *Validator) run(job func(v *Validator)) {
+	if !v.deferred {
+		job(v)
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.jobs = append(v.jobs, job)
+}
+
+// Validate runs the checks queued in deferred mode, Config.Parallelism at a
+// time, then reports whether the validator is valid, see Valid. Failures are
+// added in the order the checks were queued regardless of which one
+// finished first. Valid, Err, GetErrorMap, GetErrorDetails and Problem run
+// the checks still queued as well, against the context of the validator:
+//
+//	v.Exists(m.ProductID, "product_id", "id", "products", true)
+//	v.Unique("sku", "products", m.SKU, validator.UniqueConfig{})
+//	if !v.Validate(ctx) {
+//		return v.WriteProblem(w)
+//	}
+func (v *Validator) Validate(ctx context.Context) bool {
+	v.runJobs(ctx)
+	return v.Valid()
+}
+
+func (v *Validator) runJobs(ctx context.Context) {
+	v.mu.Lock()
+	jobs := v.jobs
+	v.jobs = nil
+	if v.memo == nil {
+		v.memo = &queryMemo{results: map[string]any{}}
+	}
+	v.mu.Unlock()
+	if len(jobs) == 0 {
+		return
+	}
+	if ctx == nil {
+		ctx = v.Context()
+	}
+	parallelism := v.parallelism
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
+	buffers := make([]*Validator, len(jobs))
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		buffers[i] = v.buffer(ctx)
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(buffer *Validator) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			job(buffer)
+		}(buffers[i])
+	}
+	wg.Wait()
+	for _, buffer := range buffers {
+		v.merge(buffer)
+	}
+}
+
+// buffer returns a validator running a deferred check against ctx, sharing
+// the database settings of v but collecting its own failures. It has no
+// Data, which the checks running concurrently would race on.
+func (v *Validator) buffer(ctx context.Context) *Validator {
+	return &Validator{
+		T:      v.T,
+		Conn:   v.Conn,
+		QB:     v.QB,
+		Scopes: v.Scopes,
+		Error: &js.ValidationError{
+			Causes: []*js.ValidationError{},
+		},
+		ctx:          ctx,
+		queryTimeout: v.queryTimeout,
+		tables:       v.tables,
+		memo:         v.memo,
+		cache:        v.cache,
+		cacheTTL:     v.cacheTTL,
+		cachedTables: v.cachedTables,
+		dialect:      v.dialect,
+		dialectSet:   v.dialectSet,
+	}
+}
+
+// merge adds the failures collected by buffer to v.
+func (v *Validator) merge(buffer *Validator) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.Error.Causes = append(v.Error.Causes, buffer.Error.Causes...)
+	if len(buffer.details) != 0 && v.details == nil {
+		v.details = make(map[*js.ValidationError]ErrorDetail)
+	}
+	for cause, detail := range buffer.details {
+		v.details[cause] = detail
+	}
+	v.errs = append(v.errs, buffer.errs...)
+}
+
+// snapshot dereferences a non nil pointer so a deferred check sees the value
+// it was called with.
+func snapshot(value any) any {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return value
+	}
+	return rv.Elem().Interface()
+}
@@ -0,0 +1,130 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// existsEven answers an EXISTS query with whether its first argument is even.
+func existsEven(_ context.Context, dest any, _ string, args ...any) error {
+	time.Sleep(time.Millisecond)
+	*dest.(*bool) = args[0].(int)%2 == 0
+	return nil
+}
+
+func TestDeferredFlush(t *testing.T) {
+	tests := []struct {
+		name  string
+		flush func(v *Validator) []string
+	}{
+		{
+			name: "Validate",
+			flush: func(v *Validator) []string {
+				v.Validate(context.Background())
+				return errorKeys(v.GetErrorDetails())
+			},
+		},
+		{
+			name: "GetErrorMap",
+			flush: func(v *Validator) []string {
+				keys := []string{}
+				for key := range v.GetErrorMap() {
+					keys = append(keys, key)
+				}
+				return keys
+			},
+		},
+		{
+			name: "GetErrorDetails",
+			flush: func(v *Validator) []string {
+				return errorKeys(v.GetErrorDetails())
+			},
+		},
+		{
+			name: "Problem",
+			flush: func(v *Validator) []string {
+				keys := []string{}
+				for pointer := range v.Problem().Errors {
+					keys = append(keys, pointer[1:])
+				}
+				return keys
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queries atomic.Int32
+			conn := &stubConn{
+				get: func(
+					ctx context.Context,
+					dest any,
+					query string,
+					args ...any,
+				) error {
+					queries.Add(1)
+					return existsEven(ctx, dest, query, args...)
+				},
+			}
+			v, err := newTestValidator(
+				t,
+				&Config{Conn: conn, Deferred: true, Parallelism: 3},
+				"application/json",
+				`{}`,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range 10 {
+				v.Exists(i, fmt.Sprintf("id%d", i), "id", "items", true)
+			}
+			if queries.Load() != 0 {
+				t.Fatal("checks ran before being flushed")
+			}
+			keys := tt.flush(v)
+			if len(keys) != 5 {
+				t.Fatalf("failed keys %v, want the 5 odd ids", keys)
+			}
+			if queries.Load() != 10 {
+				t.Fatalf("%d queries, want 10", queries.Load())
+			}
+			if causes := v.Error.Causes; causes[0].InstanceLocation != "id1" ||
+				causes[4].InstanceLocation != "id9" {
+				t.Fatal("failures aren't in the order the checks were queued")
+			}
+		})
+	}
+}
+
+func TestDeferredErr(t *testing.T) {
+	failed := errors.New("connection reset")
+	conn := &stubConn{
+		get: func(context.Context, any, string, ...any) error {
+			return failed
+		},
+	}
+	v, err := newTestValidator(
+		t,
+		&Config{Conn: conn, Deferred: true},
+		"application/json",
+		`{}`,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v.Exists(1, "id", "id", "items", true)
+	if !errors.Is(v.Err(), failed) {
+		t.Fatalf("Err() = %v, want the query failure", v.Err())
+	}
+}
+
+func errorKeys(details ErrorDetails) []string {
+	keys := make([]string, 0, len(details))
+	for key := range details {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// Dialect selects the placeholders and identifier quoting of the queries
+// built by the database checks.
+type Dialect string
+
+const (
+	DialectPostgres Dialect = "postgres"
+	DialectMySQL    Dialect = "mysql"
+	DialectSQLite   Dialect = "sqlite"
+)
+
+// UnsupportedDialectError is returned by NewValidator for a Config.Dialect
+// that isn't one of the Dialect constants.
+type UnsupportedDialectError struct {
+	Dialect Dialect
+}
+
+func (e *UnsupportedDialectError) Error() string {
+	return "unsupported dialect: " + string(e.Dialect)
+}
+
+// supported reports whether d is one of the Dialect constants.
+func (d Dialect) supported() bool {
+	switch d {
+	case DialectPostgres, DialectMySQL, DialectSQLite:
+		return true
+	}
+	return false
+}
+
+// inferDialect returns the dialect matching the placeholders of qb, ? is
+// taken for MySQL whose quoting SQLite accepts as well. Postgres is the
+// default.
+func inferDialect(qb *squirrel.StatementBuilderType) Dialect {
+	if qb == nil {
+		return DialectPostgres
+	}
+	query, _, err := qb.Select("1").Where("1 = ?", 1).ToSql()
+	if err == nil && strings.HasSuffix(query, "?") {
+		return DialectMySQL
+	}
+	return DialectPostgres
+}
+
+// quote is the character quoting identifiers in d.
+func (d Dialect) quote() byte {
+	if d == DialectMySQL {
+		return '`'
+	}
+	return '"'
+}
+
+// placeholder is the placeholder format of d.
+func (d Dialect) placeholder() squirrel.PlaceholderFormat {
+	switch d {
+	case DialectMySQL, DialectSQLite:
+		return squirrel.Question
+	default:
+		return squirrel.Dollar
+	}
+}
@@ -0,0 +1,75 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+)
+
+func TestDialectQuery(t *testing.T) {
+	dollar := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	question := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question)
+	tests := []struct {
+		name    string
+		qb      *squirrel.StatementBuilderType
+		dialect Dialect
+		want    string
+	}{
+		{
+			name: "default",
+			want: `SELECT "id", "iso", "phone_code" FROM "countries" ` +
+				`WHERE ("phone_code" = $1 OR "iso" = $2)`,
+		},
+		{
+			name: "inferred from qb",
+			qb:   &question,
+			want: "SELECT `id`, `iso`, `phone_code` FROM `countries` " +
+				"WHERE (`phone_code` = ? OR `iso` = ?)",
+		},
+		{
+			name:    "explicit over qb",
+			qb:      &dollar,
+			dialect: DialectMySQL,
+			want: "SELECT `id`, `iso`, `phone_code` FROM `countries` " +
+				"WHERE (`phone_code` = ? OR `iso` = ?)",
+		},
+		{
+			name:    "sqlite",
+			qb:      &dollar,
+			dialect: DialectSQLite,
+			want: `SELECT "id", "iso", "phone_code" FROM "countries" ` +
+				`WHERE ("phone_code" = ? OR "iso" = ?)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := newTestValidator(
+				t,
+				&Config{QB: tt.qb, Dialect: tt.dialect},
+				"",
+				"",
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			query, _, err := v.countryQuery("218", "LY")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != tt.want {
+				t.Fatalf("got %s\nwant %s", query, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnsupportedDialect(t *testing.T) {
+	for _, dialect := range []Dialect{"MySQL", "postgresql", "oracle"} {
+		_, err := newTestValidator(t, &Config{Dialect: dialect}, "", "")
+		var unsupported *UnsupportedDialectError
+		if !errors.As(err, &unsupported) || unsupported.Dialect != dialect {
+			t.Errorf("%s: got %v, want an unsupported dialect", dialect, err)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package validator
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultMaxBodySize limits the request body when Config.MaxBodySize isn't
+// set, except for urlencoded forms and multipart bodies.
+const DefaultMaxBodySize = 32 << 20 // 32mb
+
+// DefaultMaxDecodedBodySize limits the size of a decompressed request body
+// when Config.MaxDecodedBodySize isn't set.
+const DefaultMaxDecodedBodySize = 32 << 20 // 32mb
+
+// DefaultMaxFormBodySize limits urlencoded form bodies when
+// Config.MaxBodySize isn't set, matching http.Request.ParseForm.
+const DefaultMaxFormBodySize = 10 << 20 // 10mb
+
+// BodyTooLargeError is returned by ParseMax when the request body exceeds
+// Config.MaxBodySize, or its decompressed content exceeds
+// Config.MaxDecodedBodySize. It maps to 413 Content Too Large.
+type BodyTooLargeError struct {
+	Limit   int64
+	Decoded bool
+}
+
+func (e *BodyTooLargeError) Error() string {
+	if e.Decoded {
+		return "decompressed request body exceeds " +
+			strconv.FormatInt(e.Limit, 10) + " bytes"
+	}
+	return "request body exceeds " + strconv.FormatInt(e.Limit, 10) + " bytes"
+}
+
+// limitReader fails with a *BodyTooLargeError once more than limit bytes
+// are read, unlike io.LimitReader which silently truncates.
+type limitReader struct {
+	r         io.Reader
+	remaining int64
+	err       *BodyTooLargeError
+}
+
+func newLimitReader(r io.Reader, limit int64, decoded bool) *limitReader {
+	return &limitReader{
+		r:         r,
+		remaining: limit,
+		err:       &BodyTooLargeError{Limit: limit, Decoded: decoded},
+	}
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	if int64(n) > l.remaining {
+		l.remaining = -1
+		return 0, l.err
+	}
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// body wraps the body of req so it's decoded according to its
+// Content-Encoding and bounded by the configured limits.
+func (v *Validator) body(req *http.Request) (io.ReadCloser, error) {
+	var r io.Reader = req.Body
+	// forms keep the cap of http.Request.ParseForm unless a limit is set
+	formCap := v.maxBodySize == 0 && isForm(req)
+	limit := v.maxBodySize
+	if limit == 0 {
+		limit = defaultBodySize(req)
+	}
+	if limit > 0 {
+		r = newLimitReader(r, limit, false)
+	}
+	encodings := strings.Split(req.Header.Get("Content-Encoding"), ",")
+	decoded := false
+	// encodings are listed in the order they were applied
+	for i := len(encodings) - 1; i >= 0; i-- {
+		encoding := strings.ToLower(strings.TrimSpace(encodings[i]))
+		var err error
+		switch encoding {
+		case "", "identity":
+			continue
+		case "gzip", "x-gzip":
+			r, err = gzip.NewReader(r)
+		case "deflate":
+			r, err = newDeflateReader(r)
+		default:
+			return nil, &UnsupportedContentTypeError{Encoding: encoding}
+		}
+		if err == io.EOF {
+			// an empty body stays empty whatever its encoding
+			r = http.NoBody
+			break
+		}
+		if err != nil {
+			var tooLarge *BodyTooLargeError
+			if errors.As(err, &tooLarge) {
+				return nil, tooLarge
+			}
+			return nil, err
+		}
+		decoded = true
+	}
+	if decoded {
+		limit = v.maxDecodedBodySize
+		if limit <= 0 {
+			limit = DefaultMaxDecodedBodySize
+			if formCap {
+				limit = DefaultMaxFormBodySize
+			}
+		}
+		r = newLimitReader(r, limit, true)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{r, req.Body}, nil
+}
+
+// newDeflateReader reads a deflate body, which per RFC 9110 is zlib
+// wrapped, but is sent as raw deflate by some clients.
+func newDeflateReader(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(2)
+	if err == io.EOF && len(header) > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+	cmf, flg := uint16(header[0]), uint16(header[1])
+	if cmf&0x0f == 8 && (cmf<<8|flg)%31 == 0 {
+		return zlib.NewReader(br)
+	}
+	return flate.NewReader(br), nil
+}
+
+// defaultBodySize returns the limit of the body of req when
+// Config.MaxBodySize isn't set. Multipart bodies aren't limited, their files
+// are stored on disk past the memory limit of ParseMax.
+func defaultBodySize(req *http.Request) int64 {
+	switch bodyMediaType(req) {
+	case "application/x-www-form-urlencoded":
+		return DefaultMaxFormBodySize
+	case "multipart/form-data":
+		return 0
+	}
+	return DefaultMaxBodySize
+}
+
+// isForm reports whether req holds an urlencoded form body.
+func isForm(req *http.Request) bool {
+	return bodyMediaType(req) == "application/x-www-form-urlencoded"
+}
+
+// bodyMediaType returns the media type of the body of req, or an empty
+// string if its Content-Type can't be parsed.
+func bodyMediaType(req *http.Request) string {
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	return mediaType
+}
@@ -0,0 +1,184 @@
+package validator
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func compress(t *testing.T, encoding, body string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	case "raw deflate":
+		fw, err := flate.NewWriter(&buf, flate.DefaultCompression)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w = fw
+	default:
+		return []byte(body)
+	}
+	if _, err := w.Write([]byte(body)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestBodyEncoding(t *testing.T) {
+	large := `{"name":"` + strings.Repeat("a", 1000) + `"}`
+	tests := []struct {
+		name        string
+		compression string
+		header      string
+		body        string
+		chunked     bool
+		config      Config
+		want        string
+		tooLarge    bool
+		decoded     bool
+		unsupported bool
+	}{
+		{
+			name:        "gzip",
+			compression: "gzip",
+			header:      "gzip",
+			body:        `{"name":"ali"}`,
+			want:        "ali",
+		},
+		{
+			name:        "deflate",
+			compression: "deflate",
+			header:      "deflate",
+			body:        `{"name":"ali"}`,
+			want:        "ali",
+		},
+		{
+			name:        "raw deflate",
+			compression: "raw deflate",
+			header:      "deflate",
+			body:        `{"name":"ali"}`,
+			want:        "ali",
+		},
+		{
+			name:   "identity",
+			header: "identity",
+			body:   `{"name":"ali"}`,
+			want:   "ali",
+		},
+		{
+			name:        "unsupported",
+			header:      "br",
+			body:        `{"name":"ali"}`,
+			unsupported: true,
+		},
+		{
+			name:    "empty gzip",
+			header:  "gzip",
+			chunked: true,
+		},
+		{
+			name:    "empty deflate",
+			header:  "deflate",
+			chunked: true,
+		},
+		{
+			name:     "body too large",
+			body:     large,
+			config:   Config{MaxBodySize: 100},
+			tooLarge: true,
+		},
+		{
+			name:        "decoded body too large",
+			compression: "gzip",
+			header:      "gzip",
+			body:        large,
+			config:      Config{MaxBodySize: 100, MaxDecodedBodySize: 500},
+			tooLarge:    true,
+			decoded:     true,
+		},
+		{
+			name:        "within limits",
+			compression: "gzip",
+			header:      "gzip",
+			body:        large,
+			config:      Config{MaxBodySize: 100, MaxDecodedBodySize: 2000},
+			want:        strings.Repeat("a", 1000),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/",
+				bytes.NewReader(compress(t, tt.compression, tt.body)),
+			)
+			req.Header.Set("Content-Type", "application/json")
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			if tt.header != "" {
+				req.Header.Set("Content-Encoding", tt.header)
+			}
+			c := tt.config
+			c.Request = req
+			v, err := NewValidator(&c)
+			var tooLarge *BodyTooLargeError
+			var unsupported *UnsupportedContentTypeError
+			switch {
+			case tt.tooLarge:
+				if !errors.As(err, &tooLarge) ||
+					tooLarge.Decoded != tt.decoded {
+					t.Fatalf("got %v, want a body too large", err)
+				}
+			case tt.unsupported:
+				if !errors.As(err, &unsupported) {
+					t.Fatalf("got %v, want an unsupported encoding", err)
+				}
+			case err != nil:
+				t.Fatal(err)
+			default:
+				if got := v.Data.Get("name"); got != tt.want {
+					t.Fatalf("name = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBodyDefaultLimit(t *testing.T) {
+	body := `{"name":"` + strings.Repeat("a", DefaultMaxBodySize) + `"}`
+	_, err := newTestValidator(t, nil, "application/json", body)
+	var tooLarge *BodyTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("err = %v, want *BodyTooLargeError", err)
+	}
+	if tooLarge.Limit != DefaultMaxBodySize {
+		t.Errorf("Limit = %d, want %d", tooLarge.Limit, DefaultMaxBodySize)
+	}
+
+	_, err = newTestValidator(
+		t,
+		&Config{MaxBodySize: -1},
+		"application/json",
+		body,
+	)
+	if err != nil {
+		t.Errorf("err = %v with no limit, want nil", err)
+	}
+}
@@ -8,7 +8,10 @@ func AssignENUM[T ~string](
 ) *T {
 	if v.Data.KeyExists(key) {
 		v.Permit(key, allowedScopes)
-		if val := v.Data.Values.Get(key); val != "" {
+		if v.Data.IsNull(key) {
+			return assignNull(v, key, property)
+		}
+		if val, ok := v.Data.assigned(key); ok {
 			if property == nil {
 				property = new(T)
 			}
@@ -26,11 +29,14 @@ func UnmarshalIntoNullable[T any](
 ) *T {
 	if v.Data.KeyExists(key) {
 		v.Permit(key, allowedScopes)
+		if v.Data.IsNull(key) {
+			return assignNull(v, key, property)
+		}
 		if property == nil {
 			property = new(T)
 		}
 		if err := v.Data.GetAndUnmarshalJSON(key, property); err != nil {
-			v.Check(false, key, err.Error())
+			v.CheckCode(false, key, CodeJSON, nil, err.Error())
 		}
 	}
 	return property
@@ -0,0 +1,97 @@
+package validator
+
+import (
+	"github.com/Masterminds/squirrel"
+)
+
+// ExistsWhere checks that a row of table matches every predicate, reporting
+// the ValidateExistsInDB message at key otherwise. It declares ownership and
+// state checks that Exists can't express:
+//
+//	if m.ProductID != nil {
+//		v.ExistsWhere(
+//			"product_id",
+//			"products",
+//			squirrel.Eq{"id": *m.ProductID},
+//			squirrel.Eq{"vendor_id": vendorID},
+//			squirrel.Expr("is_active"),
+//		)
+//	}
+//
+// squirrel.Eq calls Value on a driver.Valuer, which panics for a nil
+// *uuid.UUID, so optional ids are checked for nil first. Column names in
+// predicates are written as is, only table is quoted and checked against
+// Config.Tables.
+func (v *Validator) ExistsWhere(
+	key, table string,
+	where ...squirrel.Sqlizer,
+) {
+	v.run(func(v *Validator) {
+		if exists, ok := v.existsWhere(key, table, true, where...); ok {
+			v.CheckCode(
+				exists,
+				key,
+				CodeNotExists,
+				nil,
+				v.T.ValidateExistsInDB(),
+			)
+		}
+	})
+}
+
+// existsWhere runs the query of ExistsWhere, ok is false when it failed and
+// the failure was recorded at key. A result that isn't shared is only
+// remembered by the request, see cached.
+func (v *Validator) existsWhere(
+	key, table string,
+	shared bool,
+	where ...squirrel.Sqlizer,
+) (exists, ok bool) {
+	query, args, err := v.existsQuery(table, where...)
+	if err != nil {
+		v.queryFailed(key, err)
+		return false, false
+	}
+	cacheTable := ""
+	if shared {
+		cacheTable = table
+	}
+	if err := v.get(cacheTable, &exists, query, args...); err != nil {
+		v.queryFailed(key, err)
+		return false, false
+	}
+	return exists, true
+}
+
+// existsQuery builds the query checking that a row of table matches every
+// predicate:
+//
+//	SELECT EXISTS(SELECT 1 FROM "table" WHERE vendor_id = $1 AND is_active)
+func (v *Validator) existsQuery(
+	table string,
+	where ...squirrel.Sqlizer,
+) (string, []any, error) {
+	quoted, err := v.table(table)
+	if err != nil {
+		return "", nil, err
+	}
+	qb := v.builder()
+	sub := qb.Select("1").From(quoted)
+	for _, predicate := range where {
+		sub = sub.Where(predicate)
+	}
+	return qb.Select().Column(squirrel.Expr("EXISTS(?)", sub)).ToSql()
+}
+
+// columnEq is the predicate "column" = value with column quoted in the
+// dialect of v.
+func (v *Validator) columnEq(
+	column string,
+	value any,
+) (squirrel.Sqlizer, error) {
+	quoted, err := v.quote(column, 1)
+	if err != nil {
+		return nil, err
+	}
+	return squirrel.Expr(quoted+" = ?", value), nil
+}
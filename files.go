@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"mime"
+	"mime/multipart"
 	"os"
 	"path/filepath"
 	"slices"
@@ -14,6 +15,15 @@ import (
 	"github.com/h2non/filetype"
 )
 
+// FileRules limits the files accepted by AssignFiles and AssignImages, a zero
+// value means no limit.
+type FileRules struct {
+	// Min and Max number of files sent with the key
+	Min, Max int
+	// MaxSize of each file in bytes
+	MaxSize int64
+}
+
 type FileData struct {
 	OriginalFileName,
 	FileName,
@@ -40,72 +50,218 @@ func (v *Validator) AssignFile(
 ) (*FileData, error) {
 	v.SaveOldFileDists(*fileName)
 
-	var fileData FileData
-
 	if !v.Data.FileExists(key) && required {
-		v.Check(false, key, v.T.ValidateRequired())
+		v.CheckCode(false, key, CodeRequired, nil, v.T.ValidateRequired())
 		return nil, errors.New(v.T.ValidateRequired())
 	}
 
 	if v.Data.FileExists(key) {
 		v.Permit(key, allowedScopes)
-		f := v.Data.GetFile(key)
-		_, params, err := mime.ParseMediaType(
-			f.Header.Get("Content-Disposition"),
-		)
+		fileData, filepathDist, err := v.writeFile(v.Data.GetFile(key))
 		if err != nil {
 			return nil, err
 		}
+		v.newFile = filepathDist
+		v.DeleteOldFile()
+		return fileData, nil
+	}
+	return &FileData{}, nil
+}
 
-		filenameParam := params["filename"]
-		fileData.OriginalFileName = filenameParam
-		fileName, err := v.fileName(filenameParam)
-		if err != nil {
-			return nil, err
+// AssignFiles writes every file sent with key the same way AssignFile does.
+// The count and size of the files are checked against rules and every file is
+// checked before any of them is written, failures are reported at key.N and
+// nothing is written. If writing fails the files written so far are removed.
+//
+// Written files can be removed with DeleteNewFiles if the request fails
+// afterwards.
+func (v *Validator) AssignFiles(
+	key string,
+	rules FileRules,
+	required bool,
+	allowedScopes ...string,
+) ([]*FileData, error) {
+	files := v.Data.GetFiles(key)
+	if !v.checkFiles(key, files, rules, required, allowedScopes) {
+		return nil, nil
+	}
+	causes := len(v.Error.Causes)
+	for i, f := range files {
+		if _, err := v.fileName(f.Filename); err != nil {
+			v.CheckCode(
+				false,
+				fmt.Sprintf("%s.%d", key, i),
+				CodeFileExtension,
+				Params{"extension": filepath.Ext(f.Filename)},
+				err.Error(),
+			)
+			continue
 		}
-
-		fileData.FileName = fileName
-		fileData.FileSize = int(f.Size)
-		// fileData.FileType = f.Header.Get("Content-Type")
-
-		fileBytes, err := v.Data.GetFileBytes(key)
+		fileBytes, err := readFileHeader(f)
 		if err != nil {
 			return nil, err
 		}
+		if !filetype.IsImage(fileBytes) {
+			v.CheckCode(
+				false,
+				fmt.Sprintf("%s.%d", key, i),
+				CodeNotImage,
+				nil,
+				v.T.FileIsNotAnImage(),
+			)
+		}
+	}
+	if len(v.Error.Causes) != causes {
+		return nil, nil
+	}
 
-		ft, err := filetype.Match(fileBytes)
+	written := []string{}
+	filesData := make([]*FileData, 0, len(files))
+	for _, f := range files {
+		fileData, filepathDist, err := v.writeFile(f)
 		if err != nil {
+			for _, dist := range written {
+				v.deleteFile(dist)
+			}
 			return nil, err
 		}
-		if !filetype.IsImage(fileBytes) {
+		written = append(written, filepathDist)
+		filesData = append(filesData, fileData)
+	}
+	v.newFiles = append(v.newFiles, written...)
+	return filesData, nil
+}
 
-			err := errors.New(v.T.FileIsNotAnImage())
-			return nil, err
+// checkFiles checks the files sent with key against the count and size limits
+// of rules, reporting whether they passed.
+func (v *Validator) checkFiles(
+	key string,
+	files []*multipart.FileHeader,
+	rules FileRules,
+	required bool,
+	allowedScopes []string,
+) bool {
+	if len(files) == 0 {
+		if required {
+			v.CheckCode(false, key, CodeRequired, nil, v.T.ValidateRequired())
+		}
+		return false
+	}
+	v.Permit(key, allowedScopes)
+	ok := true
+	if rules.Min > 0 && len(files) < rules.Min {
+		v.CheckCode(
+			false,
+			key,
+			CodeMinFiles,
+			Params{"min": rules.Min},
+			v.Message(
+				CodeMinFiles,
+				Params{"min": rules.Min},
+				fmt.Sprintf("must have at least %d files", rules.Min),
+			),
+		)
+		ok = false
+	}
+	if rules.Max > 0 && len(files) > rules.Max {
+		v.CheckCode(
+			false,
+			key,
+			CodeMaxFiles,
+			Params{"max": rules.Max},
+			v.Message(
+				CodeMaxFiles,
+				Params{"max": rules.Max},
+				fmt.Sprintf("must have at most %d files", rules.Max),
+			),
+		)
+		ok = false
+	}
+	if rules.MaxSize > 0 {
+		params := Params{"max": rules.MaxSize}
+		for i, f := range files {
+			if f.Size > rules.MaxSize {
+				v.CheckCode(
+					false,
+					fmt.Sprintf("%s.%d", key, i),
+					CodeFileSize,
+					params,
+					v.Message(
+						CodeFileSize,
+						params,
+						fmt.Sprintf(
+							"must not be larger than %d bytes",
+							rules.MaxSize,
+						),
+					),
+				)
+				ok = false
+			}
 		}
-		fileData.FileType = ft.MIME.Value
+	}
+	return ok
+}
 
-		// first 8 bytes to calculate file checksum, more takes performance
-		fileChecksum := CheckSumMD5(fileBytes, 8192)
-		fileData.FileCheckSum = fmt.Sprintf("%x", fileChecksum)
+// writeFile writes a multipart form file to the private files directory,
+// returning its data and OS path.
+func (v *Validator) writeFile(
+	f *multipart.FileHeader,
+) (*FileData, string, error) {
+	var fileData FileData
 
-		filepathVal := filepath.Join("private", "files", fileName)
-		filepathDist := v.GetRootPath(filepathVal)
-		fileData.FilePath = filepathVal
+	_, params, err := mime.ParseMediaType(
+		f.Header.Get("Content-Disposition"),
+	)
+	if err != nil {
+		return nil, "", err
+	}
 
-		// Create a new file in the uploads directory
-		dist, err := os.Create(filepath.Clean(filepathDist))
-		if err != nil {
-			return nil, err
-		}
-		defer dist.Close()
+	filenameParam := params["filename"]
+	fileData.OriginalFileName = filenameParam
+	fileName, err := v.fileName(filenameParam)
+	if err != nil {
+		return nil, "", err
+	}
 
-		if _, err := dist.WriteString(string(fileBytes)); err != nil {
-			return nil, err
-		}
-		v.newFile = filepathDist
-		v.DeleteOldFile()
+	fileData.FileName = fileName
+	fileData.FileSize = int(f.Size)
+	// fileData.FileType = f.Header.Get("Content-Type")
+
+	fileBytes, err := readFileHeader(f)
+	if err != nil {
+		return nil, "", err
+	}
+
+	ft, err := filetype.Match(fileBytes)
+	if err != nil {
+		return nil, "", err
 	}
-	return &fileData, nil
+	if !filetype.IsImage(fileBytes) {
+
+		err := errors.New(v.T.FileIsNotAnImage())
+		return nil, "", err
+	}
+	fileData.FileType = ft.MIME.Value
+
+	// first 8 bytes to calculate file checksum, more takes performance
+	fileChecksum := CheckSumMD5(fileBytes, 8192)
+	fileData.FileCheckSum = fmt.Sprintf("%x", fileChecksum)
+
+	filepathVal := filepath.Join("private", "files", fileName)
+	filepathDist := v.GetRootPath(filepathVal)
+	fileData.FilePath = filepathVal
+
+	// Create a new file in the uploads directory
+	dist, err := os.Create(filepath.Clean(filepathDist))
+	if err != nil {
+		return nil, "", err
+	}
+	defer dist.Close()
+
+	if _, err := dist.WriteString(string(fileBytes)); err != nil {
+		return nil, "", err
+	}
+	return &fileData, filepathDist, nil
 }
 
 // DeleteOldFile removes an existing image and its thumb
@@ -165,6 +321,15 @@ func (v *Validator) DeleteNewFile() {
 	}
 }
 
+// DeleteNewFiles removes every file and image written by AssignFiles and
+// AssignImages.
+func (v *Validator) DeleteNewFiles() {
+	for _, dist := range v.newFiles {
+		v.deleteFile(dist)
+	}
+	v.newFiles = nil
+}
+
 // SaveOldFileDists sets old file path instead of url img,
 // thumb values on validator.
 func (v *Validator) SaveOldFileDists(filename string) {
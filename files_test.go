@@ -0,0 +1,67 @@
+package validator
+
+import (
+	"mime/multipart"
+	"testing"
+)
+
+func TestCheckFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		sizes   []int64
+		rules   FileRules
+		key     string
+		code    string
+		message string
+	}{
+		{
+			name:    "too few",
+			sizes:   []int64{1},
+			rules:   FileRules{Min: 2},
+			key:     "files",
+			code:    CodeMinFiles,
+			message: "must have at least 2 files",
+		},
+		{
+			name:    "too many",
+			sizes:   []int64{1, 1},
+			rules:   FileRules{Max: 1},
+			key:     "files",
+			code:    CodeMaxFiles,
+			message: "must have at most 1 file",
+		},
+		{
+			name:    "too large",
+			sizes:   []int64{1, 3 << 20},
+			rules:   FileRules{MaxSize: 2 << 20},
+			key:     "files.1",
+			code:    CodeFileSize,
+			message: "must not be larger than 2 MB",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := newTestValidator(t, nil, "application/json", `{}`)
+			if err != nil {
+				t.Fatal(err)
+			}
+			files := make([]*multipart.FileHeader, len(tt.sizes))
+			for i, size := range tt.sizes {
+				files[i] = &multipart.FileHeader{
+					Filename: "a.txt",
+					Size:     size,
+				}
+			}
+			if v.checkFiles("files", files, tt.rules, true, nil) {
+				t.Fatal("checkFiles passed")
+			}
+			details := v.GetErrorDetails()[tt.key]
+			if len(details) != 1 {
+				t.Fatalf("details of %s: %v", tt.key, v.GetErrorDetails())
+			}
+			if details[0].Code != tt.code || details[0].Message != tt.message {
+				t.Fatalf("got %+v", details[0])
+			}
+		})
+	}
+}
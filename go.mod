@@ -11,6 +11,7 @@ require (
 	github.com/m-row/finder v0.0.6
 	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1
 	github.com/ttacon/libphonenumber v1.2.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,135 @@
+package validator
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+)
+
+var identifierPart = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*$`)
+
+// InvalidIdentifierError is met when a table or column name handed to a
+// database check isn't a valid identifier, it's reported by Err.
+type InvalidIdentifierError struct {
+	Name string
+}
+
+func (e *InvalidIdentifierError) Error() string {
+	return "invalid sql identifier: " + e.Name
+}
+
+// TableNotAllowedError is met when a database check targets a table missing
+// from Config.Tables, it's reported by Err.
+type TableNotAllowedError struct {
+	Table string
+}
+
+func (e *TableNotAllowedError) Error() string {
+	return "table not allowed: " + e.Table
+}
+
+// quoteIdentifier validates name, made of at most maxParts dot separated
+// parts (schema.table), and quotes each part with quote so it's taken
+// verbatim:
+//
+//	public.Users => "public"."Users"
+//
+// A part already quoted is kept as is.
+func quoteIdentifier(name string, maxParts int, quote byte) (string, error) {
+	parts, err := identifierParts(name, maxParts, quote)
+	if err != nil {
+		return "", err
+	}
+	return quoteParts(parts, quote), nil
+}
+
+// identifierParts validates name like quoteIdentifier and returns its parts
+// unquoted: "public"."Users" and public.Users both are [public Users].
+func identifierParts(name string, maxParts int, quote byte) ([]string, error) {
+	parts := strings.Split(name, ".")
+	if len(parts) > maxParts {
+		return nil, &InvalidIdentifierError{Name: name}
+	}
+	q := string(quote)
+	for i, part := range parts {
+		if unquoted, ok := unquoteIdentifier(part, q); ok {
+			parts[i] = unquoted
+		} else if !identifierPart.MatchString(part) {
+			return nil, &InvalidIdentifierError{Name: name}
+		}
+	}
+	return parts, nil
+}
+
+// quoteParts quotes each of parts with quote and joins them with dots.
+func quoteParts(parts []string, quote byte) string {
+	q := string(quote)
+	quoted := make([]string, len(parts))
+	for i, part := range parts {
+		quoted[i] = q + strings.ReplaceAll(part, q, q+q) + q
+	}
+	return strings.Join(quoted, ".")
+}
+
+// canonicalTable returns the unquoted form of a table name that Config.Tables
+// are matched by, or name itself when it isn't a valid identifier.
+func canonicalTable(name string, quote byte) string {
+	parts, err := identifierParts(name, 2, quote)
+	if err != nil {
+		return name
+	}
+	return strings.Join(parts, ".")
+}
+
+// unquoteIdentifier returns the content of an identifier quoted with q with
+// its doubled quotes undone.
+func unquoteIdentifier(part, q string) (string, bool) {
+	if len(part) < 3 ||
+		!strings.HasPrefix(part, q) ||
+		!strings.HasSuffix(part, q) {
+		return "", false
+	}
+	inner := part[1 : len(part)-1]
+	if strings.Contains(strings.ReplaceAll(inner, q+q, ""), q) {
+		return "", false
+	}
+	return strings.ReplaceAll(inner, q+q, q), true
+}
+
+// quote quotes the identifier name in the dialect of v.
+func (v *Validator) quote(name string, maxParts int) (string, error) {
+	return quoteIdentifier(name, maxParts, v.dialect.quote())
+}
+
+// table returns the quoted name of a table, which must be listed in
+// Config.Tables when set. Names are compared unquoted, so "users" matches
+// users.
+func (v *Validator) table(name string) (string, error) {
+	quote := v.dialect.quote()
+	parts, err := identifierParts(name, 2, quote)
+	if err != nil {
+		return "", err
+	}
+	if v.tables != nil {
+		if _, found := v.tables[strings.Join(parts, ".")]; !found {
+			return "", &TableNotAllowedError{Table: name}
+		}
+	}
+	return quoteParts(parts, quote), nil
+}
+
+// builder returns QB, or a builder using the placeholders of the dialect
+// when it's not set. An explicit Config.Dialect replaces the placeholders
+// of QB.
+func (v *Validator) builder() squirrel.StatementBuilderType {
+	if v.QB != nil {
+		if v.dialectSet {
+			return v.QB.PlaceholderFormat(v.dialect.placeholder())
+		}
+		return *v.QB
+	}
+	return squirrel.StatementBuilder.PlaceholderFormat(
+		v.dialect.placeholder(),
+	)
+}
@@ -0,0 +1,128 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestQuoteIdentifier(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxParts int
+		quote    byte
+		want     string
+		invalid  bool
+	}{
+		{name: "users", maxParts: 1, quote: '"', want: `"users"`},
+		{name: "users", maxParts: 1, quote: '`', want: "`users`"},
+		{
+			name:     "public.Users",
+			maxParts: 2,
+			quote:    '"',
+			want:     `"public"."Users"`,
+		},
+		{name: `"Users"`, maxParts: 1, quote: '"', want: `"Users"`},
+		{name: `"a""b"`, maxParts: 1, quote: '"', want: `"a""b"`},
+		{name: "`a``b`", maxParts: 1, quote: '`', want: "`a``b`"},
+		{name: "public.users", maxParts: 1, quote: '"', invalid: true},
+		{name: "a.b.c", maxParts: 2, quote: '"', invalid: true},
+		{name: "users; drop", maxParts: 1, quote: '"', invalid: true},
+		{name: `"a"b"`, maxParts: 1, quote: '"', invalid: true},
+		{name: "1users", maxParts: 1, quote: '"', invalid: true},
+		{name: "", maxParts: 1, quote: '"', invalid: true},
+		{name: `""`, maxParts: 1, quote: '"', invalid: true},
+		{name: `"users"`, maxParts: 1, quote: '`', invalid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := quoteIdentifier(tt.name, tt.maxParts, tt.quote)
+			var invalid *InvalidIdentifierError
+			if tt.invalid {
+				if !errors.As(err, &invalid) {
+					t.Fatalf("got %q, %v, want an invalid identifier", got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("got %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableAllowList(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		tables  []string
+		table   string
+		want    string
+		err     bool
+	}{
+		{
+			dialect: DialectPostgres,
+			tables:  []string{"users"},
+			table:   "users",
+			want:    `"users"`,
+		},
+		{
+			dialect: DialectPostgres,
+			tables:  []string{"users"},
+			table:   `"users"`,
+			want:    `"users"`,
+		},
+		{
+			dialect: DialectPostgres,
+			tables:  []string{`"public"."users"`},
+			table:   "public.users",
+			want:    `"public"."users"`,
+		},
+		{
+			dialect: DialectMySQL,
+			tables:  []string{"users"},
+			table:   "`users`",
+			want:    "`users`",
+		},
+		{
+			dialect: DialectPostgres,
+			tables:  []string{"users"},
+			table:   "roles",
+			err:     true,
+		},
+		{
+			dialect: DialectPostgres,
+			tables:  []string{"users"},
+			table:   "Users",
+			err:     true,
+		},
+		{
+			dialect: DialectPostgres,
+			tables:  []string{"users"},
+			table:   "public.users",
+			err:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.dialect)+" "+tt.table, func(t *testing.T) {
+			v, err := newTestValidator(
+				t,
+				&Config{Dialect: tt.dialect, Tables: tt.tables},
+				"",
+				"",
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := v.table(tt.table)
+			var notAllowed *TableNotAllowedError
+			if tt.err {
+				if !errors.As(err, &notAllowed) {
+					t.Fatalf("got %q, %v, want a table not allowed", got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("got %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
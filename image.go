@@ -27,105 +27,185 @@ func (v *Validator) AssignImage(
 	v.SaveOldImgThumbDists(m)
 
 	if !v.Data.FileExists(key) && required {
-		v.Check(false, key, v.T.ValidateRequired())
+		v.CheckCode(false, key, CodeRequired, nil, v.T.ValidateRequired())
 	}
 
 	if v.Data.FileExists(key) {
 		v.Permit(key, allowedScopes)
-		img := v.Data.GetFile(key)
-		_, params, err := mime.ParseMediaType(
-			img.Header.Get("Content-Disposition"),
-		)
+		img, err := v.writeImage(v.Data.GetFile(key), m.TableName())
 		if err != nil {
 			return err
 		}
+		m.SetImg(&img.Img)
+		m.SetThumb(&img.Thumb)
+		v.newImg = img.imgDist
+		v.newThumb = img.thumbDist
+		v.DeleteOldPicture()
+	}
+	return nil
+}
 
-		filename := params["filename"]
-		imgName, ext, err := v.imageName(filename, m)
-		if err != nil {
-			return err
+// ImageData holds the img and thumb values of an image written by
+// AssignImages, in the same format AssignImage sets on a model.
+type ImageData struct {
+	Img   string
+	Thumb string
+
+	imgDist   string
+	thumbDist string
+}
+
+// AssignImages writes every image sent with key the same way AssignImage
+// does, in the uploads directory of tableName. The count and size of the
+// images are checked against rules and every image is checked before any of
+// them is written, failures are reported at key.N and nothing is written. If
+// writing fails the images written so far are removed.
+//
+// Written images can be removed with DeleteNewFiles if the request fails
+// afterwards.
+func (v *Validator) AssignImages(
+	key, tableName string,
+	rules FileRules,
+	required bool,
+	allowedScopes ...string,
+) ([]*ImageData, error) {
+	files := v.Data.GetFiles(key)
+	if !v.checkFiles(key, files, rules, required, allowedScopes) {
+		return nil, nil
+	}
+	causes := len(v.Error.Causes)
+	for i, f := range files {
+		if _, _, err := v.imageName(f.Filename, tableName); err != nil {
+			v.CheckCode(
+				false,
+				fmt.Sprintf("%s.%d", key, i),
+				CodeFileExtension,
+				Params{"extension": filepath.Ext(f.Filename)},
+				err.Error(),
+			)
+		}
+	}
+	if len(v.Error.Causes) != causes {
+		return nil, nil
+	}
+
+	written := []string{}
+	images := make([]*ImageData, 0, len(files))
+	for _, f := range files {
+		img, err := v.writeImage(f, tableName)
+		if img != nil {
+			written = append(written, img.imgDist, img.thumbDist)
 		}
-		imgBytes, err := v.Data.GetFileBytes(key)
 		if err != nil {
-			return err
+			for _, dist := range written {
+				v.deleteFile(dist)
+			}
+			return nil, err
 		}
+		images = append(images, img)
+	}
+	v.newFiles = append(v.newFiles, written...)
+	return images, nil
+}
 
-		imgVal := filepath.Join("uploads", m.TableName(), imgName)
-		thumbVal := filepath.Join(
-			"uploads",
-			m.TableName(),
-			"thumbs",
-			fmt.Sprintf("thumb_%s", imgName),
-		)
+// writeImage writes a multipart form image and its thumb to the public
+// uploads directory of tableName. The returned ImageData is not nil once the
+// image is written so that a failure writing the thumb can be cleaned up.
+func (v *Validator) writeImage(
+	img *multipart.FileHeader,
+	tableName string,
+) (*ImageData, error) {
+	_, params, err := mime.ParseMediaType(
+		img.Header.Get("Content-Disposition"),
+	)
+	if err != nil {
+		return nil, err
+	}
 
-		m.SetImg(&imgVal)
-		m.SetThumb(&thumbVal)
-
-		// public is a hidden path on live urls are in the format:
-		// https://proj.com/uploads/banners/thumbs/thumb_banners_1637_9577.jpeg
-		// thats why the database value is set without it,
-		// but the OS path is full
-		imgDist := v.GetRootPath(filepath.Join("public", imgVal))
-		thumbDist := v.GetRootPath(filepath.Join("public", thumbVal))
-
-		distpath := filepath.Join(
-			"public",
-			"uploads",
-			m.TableName(),
-			"thumbs",
-		)
-		_, err = os.Stat(distpath)
-		if err != nil {
-			if errors.Is(err, os.ErrNotExist) {
-				if err := os.MkdirAll(distpath, 0o750); err != nil {
-					return err
-				}
-				msg := fmt.Sprintf(
-					"Directory created successfully: %s",
-					distpath,
-				)
-				log.Println(msg)
+	filename := params["filename"]
+	imgName, ext, err := v.imageName(filename, tableName)
+	if err != nil {
+		return nil, err
+	}
+	imgBytes, err := readFileHeader(img)
+	if err != nil {
+		return nil, err
+	}
+
+	imgVal := filepath.Join("uploads", tableName, imgName)
+	thumbVal := filepath.Join(
+		"uploads",
+		tableName,
+		"thumbs",
+		fmt.Sprintf("thumb_%s", imgName),
+	)
+
+	// public is a hidden path on live urls are in the format:
+	// https://proj.com/uploads/banners/thumbs/thumb_banners_1637_9577.jpeg
+	// thats why the database value is set without it,
+	// but the OS path is full
+	imgDist := v.GetRootPath(filepath.Join("public", imgVal))
+	thumbDist := v.GetRootPath(filepath.Join("public", thumbVal))
+
+	distpath := filepath.Join(
+		"public",
+		"uploads",
+		tableName,
+		"thumbs",
+	)
+	_, err = os.Stat(distpath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			if err := os.MkdirAll(distpath, 0o750); err != nil {
+				return nil, err
 			}
+			msg := fmt.Sprintf(
+				"Directory created successfully: %s",
+				distpath,
+			)
+			log.Println(msg)
 		}
+	}
 
-		// Create a new file in the uploads directory
-		dist, err := os.Create(filepath.Clean(imgDist))
-		if err != nil {
-			return err
-		}
-		defer dist.Close()
+	// Create a new file in the uploads directory
+	dist, err := os.Create(filepath.Clean(imgDist))
+	if err != nil {
+		return nil, err
+	}
+	defer dist.Close()
 
-		if _, err := dist.WriteString(string(imgBytes)); err != nil {
-			return err
+	if _, err := dist.WriteString(string(imgBytes)); err != nil {
+		return nil, err
+	}
+	data := &ImageData{
+		Img:       imgVal,
+		Thumb:     thumbVal,
+		imgDist:   imgDist,
+		thumbDist: thumbDist,
+	}
+	if ext != ".svg" {
+		if err := v.generateThumb(img, thumbDist); err != nil {
+			return data, err
 		}
-		if ext != ".svg" {
-			if err := v.generateThumb(img, thumbDist); err != nil {
-				return err
-			}
-		} else {
-			// if its an svg keep the same file bytes
-			// and create a new file in the uploads thumb directory
-			thumbdist, err := os.Create(filepath.Clean(thumbDist))
-			if err != nil {
-				return err
-			}
-			defer thumbdist.Close()
+	} else {
+		// if its an svg keep the same file bytes
+		// and create a new file in the uploads thumb directory
+		thumbdist, err := os.Create(filepath.Clean(thumbDist))
+		if err != nil {
+			return data, err
+		}
+		defer thumbdist.Close()
 
-			if _, err := thumbdist.WriteString(string(imgBytes)); err != nil {
-				return err
-			}
+		if _, err := thumbdist.WriteString(string(imgBytes)); err != nil {
+			return data, err
 		}
-		v.newImg = imgDist
-		v.newThumb = thumbDist
-		v.DeleteOldPicture()
 	}
-	return nil
+	return data, nil
 }
 
 // imageName for uploaded files.
 func (v *Validator) imageName(
-	filename string,
-	m interfaces.HasImage,
+	filename, tableName string,
 ) (string, string, error) {
 	// this slice must be sorted alphabetically
 	mimetypes := []string{
@@ -148,7 +228,7 @@ func (v *Validator) imageName(
 	}
 	// Image data
 	randomNum := rand.Int63n(1_000_000) //nolint:gosec // dw
-	imgName := m.TableName() +
+	imgName := tableName +
 		"_" +
 		strconv.FormatInt(time.Now().UnixNano(), 10) +
 		"_" +
@@ -55,3 +55,18 @@ type Translation interface {
 	OTPSentSuccessfully() string
 	WalletTransactionAlreadyConfirmed() string
 }
+
+// SchemaTranslation can be implemented next to Translation to localize the
+// json schema keywords that have no matching Translation method (pattern,
+// maxItems, additionalProperties...). params holds the values parsed from the
+// failure, such as "pattern" or "max".
+type SchemaTranslation interface {
+	ValidateSchemaKeyword(keyword string, params map[string]any) string
+}
+
+// MessageTranslation can be implemented next to Translation to look messages
+// up by their code, such as "min_length", so new messages don't need a new
+// method. It returns false when the code has no message.
+type MessageTranslation interface {
+	Message(code string, params map[string]any) (string, bool)
+}
@@ -1,13 +1,36 @@
 package validator
 
 import (
-	"context"
 	"fmt"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 )
 
-// ValidateListUUIDs unmarshalls a key to a string slice
+// listID is a valid uuid of a list with its index.
+type listID struct {
+	index int
+	id    string
+}
+
+// NoDuplicates marks list keys whose elements must be distinct, a repeated
+// element is reported at its index with CodeDuplicate.
+//
+//	v.NoDuplicates("tag_ids")
+//	m.TagIDs = v.ValidateListUUIDs("tag_ids", "tags", true)
+func (v *Validator) NoDuplicates(keys ...string) {
+	if v.noDuplicates == nil {
+		v.noDuplicates = make(map[string]struct{}, len(keys))
+	}
+	for _, key := range keys {
+		v.noDuplicates[normalizeKey(key)] = struct{}{}
+	}
+}
+
+// ValidateListUUIDs unmarshalls a key to a string slice, then checks that
+// every element exists in tableName with a query per 1000 elements:
+//
+//	SELECT "id" FROM "tableName" WHERE "id" IN ($1,$2,...)
 func (v *Validator) ValidateListUUIDs(
 	fieldName, tableName string,
 	required bool,
@@ -16,39 +39,127 @@ func (v *Validator) ValidateListUUIDs(
 	arr := []string{}
 	v.UnmarshalInto(fieldName, &arr, allowedScopes...)
 	if required && len(arr) == 0 {
-		v.Check(false, fieldName, v.T.ValidateRequiredArray())
+		v.CheckCode(
+			false,
+			fieldName,
+			CodeRequiredArray,
+			nil,
+			v.T.ValidateRequiredArray(),
+		)
 	}
 	if len(arr) > 0 {
-		for index, id := range arr {
-			if _, err := uuid.Parse(id); err != nil {
-				v.Check(
-					false,
-					fmt.Sprintf("%s.%d", fieldName, index),
-					v.T.ValidateUUID(),
-				)
-			} else {
-				var exists bool
-				query := fmt.Sprintf(
-					`SELECT EXISTS(SELECT 1 FROM %s WHERE id=$1) AS exists`,
-					tableName,
-				)
-				if err := v.Conn.GetContext(
-					context.Background(),
-					&exists,
-					query,
-					id,
-				); err != nil {
-					exists = false
-				}
-				if required && !exists {
-					v.Check(
+		ids := v.listUUIDs(fieldName, arr)
+		v.run(func(v *Validator) {
+			found, err := v.foundIDs(tableName, ids, nil)
+			if err != nil {
+				v.queryFailed(fieldName, err)
+				return
+			}
+			for _, id := range ids {
+				if _, exists := found[id.id]; required && !exists {
+					v.CheckCode(
 						exists,
-						fmt.Sprintf("%s.%d", fieldName, index),
+						fmt.Sprintf("%s.%d", fieldName, id.index),
+						CodeNotExists,
+						nil,
 						v.T.ValidateExistsInDB(),
 					)
 				}
 			}
-		}
+		})
 	}
 	return &arr
 }
+
+// listUUIDs checks the syntax of every element of arr, and their uniqueness
+// when fieldName was marked with NoDuplicates, returning the valid ones in
+// their canonical form.
+func (v *Validator) listUUIDs(fieldName string, arr []string) []listID {
+	_, unique := v.noDuplicates[normalizeKey(fieldName)]
+	seen := make(map[string]int, len(arr))
+	ids := make([]listID, 0, len(arr))
+	for index, s := range arr {
+		key := fmt.Sprintf("%s.%d", fieldName, index)
+		parsed, err := uuid.Parse(s)
+		if err != nil {
+			v.CheckCode(false, key, CodeUUID, nil, v.T.ValidateUUID())
+			continue
+		}
+		id := parsed.String()
+		if first, found := seen[id]; found {
+			if unique {
+				params := Params{"index": first}
+				v.CheckCode(
+					false,
+					key,
+					CodeDuplicate,
+					params,
+					v.Message(
+						CodeDuplicate,
+						params,
+						"must not repeat element "+fmt.Sprint(first),
+					),
+				)
+			}
+			continue
+		}
+		seen[id] = index
+		ids = append(ids, listID{index: index, id: id})
+	}
+	return ids
+}
+
+// listBatchSize caps the ids of a single foundIDs query, well below the
+// 65535 bind parameters postgres allows with room left for where.
+const listBatchSize = 1000
+
+// foundIDs returns the ids of table matching ids and where, which may be nil,
+// using a query per listBatchSize ids.
+func (v *Validator) foundIDs(
+	table string,
+	ids []listID,
+	where squirrel.Sqlizer,
+) (map[string]struct{}, error) {
+	found := map[string]struct{}{}
+	if len(ids) == 0 {
+		return found, nil
+	}
+	quoted, err := v.table(table)
+	if err != nil {
+		return nil, err
+	}
+	id, err := v.quote("id", 1)
+	if err != nil {
+		return nil, err
+	}
+	for start := 0; start < len(ids); start += listBatchSize {
+		batch := ids[start:min(start+listBatchSize, len(ids))]
+		values := make([]string, len(batch))
+		for i := range batch {
+			values[i] = batch[i].id
+		}
+		q := v.builder().
+			Select(id).
+			From(quoted).
+			Where(squirrel.Eq{id: values})
+		if where != nil {
+			q = q.Where(where)
+		}
+		query, args, err := q.ToSql()
+		if err != nil {
+			return nil, err
+		}
+		rows := []string{}
+		err = v.selectRows(table, &rows, len(batch), query, args...)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			if parsed, err := uuid.Parse(row); err == nil {
+				row = parsed.String()
+			}
+			found[row] = struct{}{}
+		}
+	}
+	return found, nil
+}
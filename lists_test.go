@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/m-row/validator/interfaces"
+	"github.com/m-row/validator/translations"
+)
+
+func TestValidateListUUIDsBatches(t *testing.T) {
+	ids := make([]string, 2*listBatchSize+1)
+	for i := range ids {
+		ids[i] = `"` + uuid.NewString() + `"`
+	}
+	queries := 0
+	conn := &stubConn{
+		selectFn: func(
+			_ context.Context,
+			dest any,
+			_ string,
+			args ...any,
+		) error {
+			queries++
+			if len(args) > listBatchSize {
+				t.Errorf("%d args in a query", len(args))
+			}
+			rows := dest.(*[]string)
+			for _, arg := range args {
+				*rows = append(*rows, arg.(string))
+			}
+			return nil
+		},
+	}
+	v, err := newTestValidator(
+		t,
+		&Config{Conn: conn},
+		"application/json",
+		`{"ids":[`+strings.Join(ids, ",")+`]}`,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := v.ValidateListUUIDs("ids", "products", true)
+	if !v.Valid() {
+		t.Fatalf("errors %v %v", v.Err(), v.GetErrorMap())
+	}
+	if got == nil || len(*got) != len(ids) {
+		t.Fatalf("got %v ids", got)
+	}
+	if queries != 3 {
+		t.Fatalf("%d queries, want 3", queries)
+	}
+}
+
+func TestListDuplicateTranslated(t *testing.T) {
+	id := uuid.NewString()
+	for _, tr := range []interfaces.Translation{
+		translations.English{},
+		translations.Arabic{},
+	} {
+		v, err := newTestValidator(t, &Config{T: tr}, "application/json", `{}`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v.NoDuplicates("ids")
+		v.listUUIDs("ids", []string{id, id})
+		want, _ := tr.(interfaces.MessageTranslation).Message(
+			CodeDuplicate,
+			Params{"index": 0},
+		)
+		details := v.GetErrorDetails()["ids.1"]
+		if len(details) != 1 || details[0].Message != want {
+			t.Errorf("%T: details %+v, want %q", tr, details, want)
+		}
+	}
+}
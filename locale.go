@@ -0,0 +1,152 @@
+package validator
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/m-row/validator/interfaces"
+	"github.com/m-row/validator/translations"
+)
+
+// DefaultLocale is the locale used when Config.DefaultLocale isn't set and
+// nothing the client asked for is registered.
+const DefaultLocale = "en"
+
+// LangParam is the query parameter that overrides Accept-Language:
+// /products?lang=ar. It counts as read when Config.T is nil, so strict mode
+// doesn't reject it.
+const LangParam = "lang"
+
+var (
+	translationsMu sync.RWMutex
+	registry       = map[string]interfaces.Translation{
+		"en": translations.English{},
+		"ar": translations.Arabic{},
+	}
+)
+
+// RegisterTranslation makes t available to Negotiate under a BCP 47 language
+// tag, replacing the translation of a tag registered twice. Tags compare case
+// insensitively with _ read as -, and a regional tag also serves requests for
+// its language alone:
+//
+//	validator.RegisterTranslation("ar-LY", LibyanArabic{})
+func RegisterTranslation(tag string, t interfaces.Translation) {
+	translationsMu.Lock()
+	defer translationsMu.Unlock()
+	registry[canonicalTag(tag)] = t
+}
+
+// Negotiate picks the registered translation that best matches req, the lang
+// query parameter first then the Accept-Language header by quality. A tag
+// matches exactly or by its language (ar-EG matches ar and ar matches ar-LY),
+// fallback is used when nothing matches. It returns the chosen tag with its
+// translation.
+func Negotiate(
+	req *http.Request,
+	fallback string,
+) (string, interfaces.Translation) {
+	if fallback == "" {
+		fallback = DefaultLocale
+	}
+	translationsMu.RLock()
+	defer translationsMu.RUnlock()
+	if req != nil {
+		if lang := req.URL.Query().Get(LangParam); lang != "" {
+			if tag, found := matchTag(lang); found {
+				return tag, registry[tag]
+			}
+		}
+		accepted := acceptLanguages(req.Header.Get("Accept-Language"))
+		for _, lang := range accepted {
+			if lang == "*" {
+				break
+			}
+			if tag, found := matchTag(lang); found {
+				return tag, registry[tag]
+			}
+		}
+	}
+	if tag, found := matchTag(fallback); found {
+		return tag, registry[tag]
+	}
+	return DefaultLocale, registry[DefaultLocale]
+}
+
+// matchTag returns the registered tag matching lang, the caller holds
+// translationsMu.
+func matchTag(lang string) (string, bool) {
+	lang = canonicalTag(lang)
+	if _, found := registry[lang]; found {
+		return lang, true
+	}
+	base, _, _ := strings.Cut(lang, "-")
+	if _, found := registry[base]; found {
+		return base, true
+	}
+	// keep the choice stable when several regions share the language
+	tags := make([]string, 0, len(registry))
+	for tag := range registry {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, base+"-") {
+			return tag, true
+		}
+	}
+	return "", false
+}
+
+// acceptLanguages returns the tags of an Accept-Language header ordered by
+// quality, tags with q=0 are dropped.
+func acceptLanguages(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	langs := []weighted{}
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		if k, val, found := strings.Cut(params, "="); found &&
+			strings.TrimSpace(k) == "q" {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		langs = append(langs, weighted{tag: tag, q: q})
+	}
+	sort.SliceStable(langs, func(i, j int) bool {
+		return langs[i].q > langs[j].q
+	})
+	tags := make([]string, len(langs))
+	for i := range langs {
+		tags[i] = langs[i].tag
+	}
+	return tags
+}
+
+// canonicalTag lower cases tag and uses dashes: en_US => en-us
+func canonicalTag(tag string) string {
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+	return strings.ToLower(tag)
+}
+
+// Locale returns the tag of the translation negotiated for the request, or
+// an empty string when Config.T was set by the caller.
+func (v *Validator) Locale() string {
+	return v.locale
+}
@@ -0,0 +1,106 @@
+package validator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/m-row/validator/translations"
+)
+
+func TestAcceptLanguages(t *testing.T) {
+	tests := map[string][]string{
+		"":                          {},
+		"ar":                        {"ar"},
+		"en;q=0.5, ar, fr;q=0.8":    {"ar", "fr", "en"},
+		"ar;q=0, en":                {"en"},
+		"en;q=x, ar":                {"ar"},
+		"de, *;q=0.1, en-US;q=0.9":  {"de", "en-US", "*"},
+		"en-US , ar-EG;q=1, fr;q=1": {"en-US", "ar-EG", "fr"},
+	}
+	for header, want := range tests {
+		if got := acceptLanguages(header); !reflect.DeepEqual(got, want) {
+			t.Errorf("acceptLanguages(%q) = %v, want %v", header, got, want)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	RegisterTranslation("fr_CA", translations.English{})
+	t.Cleanup(func() {
+		translationsMu.Lock()
+		defer translationsMu.Unlock()
+		delete(registry, "fr-ca")
+	})
+	tests := []struct {
+		name     string
+		target   string
+		header   string
+		fallback string
+		want     string
+	}{
+		{name: "default", target: "/", want: "en"},
+		{name: "fallback", target: "/", fallback: "ar", want: "ar"},
+		{name: "exact", target: "/", header: "ar", want: "ar"},
+		{name: "by language", target: "/", header: "ar-EG", want: "ar"},
+		{name: "by region", target: "/", header: "fr", want: "fr-ca"},
+		{
+			name:   "case and underscore",
+			target: "/",
+			header: "FR_ca",
+			want:   "fr-ca",
+		},
+		{
+			name:   "quality",
+			target: "/",
+			header: "en;q=0.5, ar;q=0.9",
+			want:   "ar",
+		},
+		{
+			name:   "q=0 dropped",
+			target: "/",
+			header: "ar;q=0, de, en;q=0.1",
+			want:   "en",
+		},
+		{
+			name:   "unregistered skipped",
+			target: "/",
+			header: "de, ar;q=0.2",
+			want:   "ar",
+		},
+		{
+			name:     "wildcard",
+			target:   "/",
+			header:   "de, *, en;q=0.1",
+			fallback: "ar",
+			want:     "ar",
+		},
+		{
+			name:   "lang param",
+			target: "/?lang=ar",
+			header: "en",
+			want:   "ar",
+		},
+		{
+			name:   "unregistered lang param",
+			target: "/?lang=de",
+			header: "ar",
+			want:   "ar",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.header != "" {
+				req.Header.Set("Accept-Language", tt.header)
+			}
+			if got, _ := Negotiate(req, tt.fallback); got != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+	if got, _ := Negotiate(nil, "ar"); got != "ar" {
+		t.Fatalf("got %s without a request, want ar", got)
+	}
+}
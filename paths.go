@@ -0,0 +1,244 @@
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+// normalizeKey converts bracketed form keys into the dotted paths used by
+// Data and GetErrorMap:
+//
+//	items[0][name] => items.0.name
+//	address[city]  => address.city
+func normalizeKey(key string) string {
+	if !strings.ContainsAny(key, "[]") {
+		return key
+	}
+	var b strings.Builder
+	b.Grow(len(key))
+	for i := 0; i < len(key); i++ {
+		switch key[i] {
+		case '[':
+			if i+1 < len(key) && key[i+1] == ']' {
+				i++
+				continue
+			}
+			b.WriteByte('.')
+		case ']':
+		default:
+			b.WriteByte(key[i])
+		}
+	}
+	return b.String()
+}
+
+// splitParent splits a dotted path into its parent path and last segment.
+func splitParent(key string) (string, string) {
+	i := strings.LastIndexByte(key, '.')
+	if i < 0 {
+		return "", key
+	}
+	return key[:i], key[i+1:]
+}
+
+// values returns the values stored at key, an indexed path into a key holding
+// multiple values (tags.1 for tags=a&tags=b) resolves to that single value.
+func (d Data) values(key string) ([]string, bool) {
+	key = normalizeKey(key)
+	d.use(key)
+	if vals, found := d.Values[key]; found {
+		return vals, true
+	}
+	parent, last := splitParent(key)
+	if parent == "" {
+		return nil, false
+	}
+	i, err := strconv.Atoi(last)
+	if err != nil || i < 0 {
+		return nil, false
+	}
+	if vals, found := d.Values[parent]; found && i < len(vals) {
+		return vals[i : i+1], true
+	}
+	return nil, false
+}
+
+// children returns the distinct segments directly under key, sorted
+// numerically when all of them are indexes.
+func (d Data) children(key string) []string {
+	prefix := normalizeKey(key) + "."
+	seen := map[string]struct{}{}
+	segments := []string{}
+	for k := range d.Values {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		segment, _, _ := strings.Cut(k[len(prefix):], ".")
+		if _, found := seen[segment]; !found {
+			seen[segment] = struct{}{}
+			segments = append(segments, segment)
+		}
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		a, errA := strconv.Atoi(segments[i])
+		b, errB := strconv.Atoi(segments[j])
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return segments[i] < segments[j]
+	})
+	return segments
+}
+
+// isIndexed reports whether every segment is a non negative integer.
+func isIndexed(segments []string) bool {
+	for _, s := range segments {
+		if i, err := strconv.Atoi(s); err != nil || i < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// tree rebuilds the value stored under key from its flattened children,
+// indexed children become a slice, anything else a map.
+func (d Data) tree(key string) any {
+	segments := d.children(key)
+	if len(segments) == 0 {
+		vals, _ := d.values(key)
+		switch len(vals) {
+		case 0:
+			return nil
+		case 1:
+			return vals[0]
+		default:
+			arr := make([]any, len(vals))
+			for i := range vals {
+				arr[i] = vals[i]
+			}
+			return arr
+		}
+	}
+	if isIndexed(segments) {
+		arr := []any{}
+		for _, s := range segments {
+			i, _ := strconv.Atoi(s)
+			for len(arr) <= i {
+				arr = append(arr, nil)
+			}
+			arr[i] = d.tree(key + "." + s)
+		}
+		return arr
+	}
+	m := make(map[string]any, len(segments))
+	for _, s := range segments {
+		m[s] = d.tree(key + "." + s)
+	}
+	return m
+}
+
+// Len returns the number of elements at key: the length of an array or
+// object, the number of values of a repeated key, or 0 if key doesn't exist.
+//
+//	d.Len("items") // {"items": [{}, {}]}, items[0][qty]=1&items[1][qty]=2
+//	d.Len("tags")  // tags[]=a&tags[]=b
+func (d Data) Len(key string) int {
+	key = normalizeKey(key)
+	d.use(key)
+	return d.length(key)
+}
+
+// length returns the number of elements at key without marking it as used.
+func (d Data) length(key string) int {
+	if n, found := d.containers[key]; found {
+		return n
+	}
+	if segments := d.children(key); len(segments) != 0 {
+		if isIndexed(segments) {
+			last, _ := strconv.Atoi(segments[len(segments)-1])
+			return last + 1
+		}
+		return len(segments)
+	}
+	return len(d.Values[key])
+}
+
+// hasChildren reports whether any value is stored under key.
+func (d Data) hasChildren(key string) bool {
+	key = normalizeKey(key)
+	d.use(key)
+	prefix := key + "."
+	for k := range d.Values {
+		if strings.HasPrefix(k, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddJSON flattens a decoded json value into d at key, containers keep their
+// json encoding at their own path so they can still be unmarshalled as a
+// whole. It's how a Decoder records what Add can't: a nil val is a null,
+// map[string]any and []any are objects and arrays with their length, and
+// a string is sent as one so an empty string is still assigned.
+func (d *Data) AddJSON(key string, val any) error {
+	switch val := val.(type) {
+	case map[string]any:
+		if err := d.addJSONString(key, val); err != nil {
+			return err
+		}
+		d.containers[key] = len(val)
+		for k, child := range val {
+			if err := d.AddJSON(key+"."+k, child); err != nil {
+				return err
+			}
+		}
+	case []any:
+		if err := d.addJSONString(key, val); err != nil {
+			return err
+		}
+		d.containers[key] = len(val)
+		for i, child := range val {
+			if err := d.AddJSON(key+"."+strconv.Itoa(i), child); err != nil {
+				return err
+			}
+		}
+	case nil:
+		d.nulls[key] = struct{}{}
+		d.add(key, key, "")
+	case string:
+		d.jsonStrings[key] = struct{}{}
+		d.add(key, key, val)
+	case json.Number:
+		d.add(key, key, val.String())
+	default:
+		d.add(key, key, fmt.Sprint(val))
+	}
+	return nil
+}
+
+func (d *Data) addJSONString(key string, val any) error {
+	jsonVal, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+	d.add(key, key, string(jsonVal))
+	return nil
+}
+
+// assigned returns the value of key and whether it should be assigned, an
+// empty value only is when it was sent as a json string: "name": "" clears
+// name while an empty form field, as html forms send for every blank input,
+// is skipped.
+func (d Data) assigned(key string) (string, bool) {
+	val := d.Get(key)
+	if val != "" {
+		return val, true
+	}
+	_, sent := d.jsonStrings[normalizeKey(key)]
+	return val, sent
+}
@@ -1,9 +1,11 @@
 package validator
 
 import (
-	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/ttacon/libphonenumber"
 )
 
@@ -18,41 +20,51 @@ type PhoneCountry struct {
 func (v *Validator) ValidatePhone(phone string) (*PhoneCountry, string) {
 	var c PhoneCountry
 	if phone == "" {
-		v.Check(false, "phone", v.T.ValidateRequired())
+		v.CheckCode(false, "phone", CodeRequired, nil, v.T.ValidateRequired())
 		return nil, ""
 	}
 
 	if v.Data.KeyExists("region") {
-		c.ISO = v.Data.Values.Get("region")
+		c.ISO = v.Data.Get("region")
 		found := false
 		for k := range libphonenumber.GetSupportedRegions() {
 			found = found || k == c.ISO
 		}
 		if !found {
-			v.Check(false, "region", "no matching region for: "+c.ISO)
+			v.CheckCode(
+				false,
+				"region",
+				CodeRegion,
+				Params{"region": c.ISO},
+				"no matching region for: "+c.ISO,
+			)
 			return nil, ""
 		}
 	}
-	if v.Data.Values.Has("country_code") {
-		if err := v.Conn.GetContext(
-			context.Background(),
-			&c,
-			` 
-                SELECT id, iso, phone_code 
-                FROM countries 
-                WHERE phone_code = $1 OR iso = $2
-            `,
-			v.Data.Get("country_code"),
-			c.ISO,
-		); err != nil {
-			v.Check(false, "country_code", err.Error())
+	if v.Data.KeyExists("country_code") {
+		query, args, err := v.countryQuery(v.Data.Get("country_code"), c.ISO)
+		if err == nil {
+			err = v.get("countries", &c, query, args...)
+		}
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				v.queryFailed("country_code", err)
+				return nil, ""
+			}
+			v.CheckCode(
+				false,
+				"country_code",
+				CodeCountryCode,
+				nil,
+				v.T.ValidateExistsInDB(),
+			)
 			return nil, ""
 		}
 	}
 
 	num, err := libphonenumber.Parse(phone, c.ISO)
 	if err != nil {
-		v.Check(false, "phone", err.Error())
+		v.CheckCode(false, "phone", CodePhone, nil, err.Error())
 		return nil, ""
 	}
 
@@ -61,3 +73,30 @@ func (v *Validator) ValidatePhone(phone string) (*PhoneCountry, string) {
 
 	return &c, fmt.Sprintf("%d%d", cc, nn)
 }
+
+// countryQuery builds the query of the country of a phone code or region:
+//
+//	SELECT "id", "iso", "phone_code" FROM "countries"
+//	WHERE ("phone_code" = $1 OR "iso" = $2)
+func (v *Validator) countryQuery(
+	phoneCode, iso string,
+) (string, []any, error) {
+	table, err := v.table("countries")
+	if err != nil {
+		return "", nil, err
+	}
+	columns := make([]string, 3)
+	for i, name := range []string{"id", "iso", "phone_code"} {
+		if columns[i], err = v.quote(name, 1); err != nil {
+			return "", nil, err
+		}
+	}
+	return v.builder().
+		Select(columns...).
+		From(table).
+		Where(squirrel.Or{
+			squirrel.Expr(columns[2]+" = ?", phoneCode),
+			squirrel.Expr(columns[1]+" = ?", iso),
+		}).
+		ToSql()
+}
@@ -0,0 +1,142 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/m-row/validator/interfaces"
+)
+
+// ProblemContentType is the media type of a Problem.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 9457 problem details object, failures of a Validator are
+// reported in the errors extension keyed by the json pointer of the value
+// that failed:
+//
+//	{
+//	  "type": "about:blank",
+//	  "title": "...",
+//	  "status": 422,
+//	  "errors": {
+//	    "/address/city": [{"code": "required", "message": "..."}]
+//	  }
+//	}
+type Problem struct {
+	Type     string                   `json:"type"`
+	Title    string                   `json:"title"`
+	Status   int                      `json:"status"`
+	Detail   string                   `json:"detail,omitempty"`
+	Instance string                   `json:"instance,omitempty"`
+	Errors   map[string][]ErrorDetail `json:"errors,omitempty"`
+}
+
+// NewProblem returns the problem of a request that couldn't be parsed, err
+// being the error returned by NewValidator:
+//
+//   - 415 for an *UnsupportedContentTypeError
+//   - 413 for a *BodyTooLargeError
+//   - 400 for anything else, such as a malformed body
+//
+// The titles of 413 and 415 are the messages of CodeBodyTooLarge and
+// CodeUnsupportedMediaType when t has them, their status text otherwise.
+func NewProblem(t interfaces.Translation, err error) *Problem {
+	status := http.StatusBadRequest
+	title := t.BadRequest()
+	var unsupported *UnsupportedContentTypeError
+	var tooLarge *BodyTooLargeError
+	switch {
+	case errors.As(err, &unsupported):
+		status = http.StatusUnsupportedMediaType
+		title = message(
+			t,
+			CodeUnsupportedMediaType,
+			nil,
+			http.StatusText(status),
+		)
+	case errors.As(err, &tooLarge):
+		status = http.StatusRequestEntityTooLarge
+		title = message(t, CodeBodyTooLarge, nil, http.StatusText(status))
+	}
+	p := &Problem{
+		Type:   "about:blank",
+		Title:  title,
+		Status: status,
+	}
+	if err != nil {
+		p.Detail = err.Error()
+	}
+	return p
+}
+
+// Problem returns the failures of the validator as a 422 problem, or when a
+// database failure was met, see Err, a problem that doesn't disclose it:
+//
+//   - 504 when a check timed out
+//   - 503 when the request was canceled or the database couldn't be reached
+//   - 500 for anything else
+func (v *Validator) Problem() *Problem {
+	if err := v.Err(); err != nil {
+		status := http.StatusInternalServerError
+		title := v.T.InternalServerError()
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			status = http.StatusGatewayTimeout
+			title = v.Message(CodeTimeout, nil, title)
+		case errors.Is(err, context.Canceled):
+			status = http.StatusServiceUnavailable
+			title = v.Message(CodeCanceled, nil, title)
+		case unavailable(err):
+			status = http.StatusServiceUnavailable
+		}
+		return &Problem{
+			Type:   "about:blank",
+			Title:  title,
+			Status: status,
+		}
+	}
+	p := &Problem{
+		Type:   "about:blank",
+		Title:  v.T.InputValidation(),
+		Status: http.StatusUnprocessableEntity,
+		Errors: map[string][]ErrorDetail{},
+	}
+	for key, details := range v.GetErrorDetails() {
+		pointer := jsonPointer(key)
+		p.Errors[pointer] = append(p.Errors[pointer], details...)
+	}
+	return p
+}
+
+// WriteProblem writes the failures of the validator to w, see Problem.
+func (v *Validator) WriteProblem(w http.ResponseWriter) error {
+	return v.Problem().Write(w)
+}
+
+// Write writes the problem to w as application/problem+json with its status.
+func (p *Problem) Write(w http.ResponseWriter) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	_, err = w.Write(body)
+	return err
+}
+
+// jsonPointer converts a dotted error key to an RFC 6901 json pointer.
+func jsonPointer(key string) string {
+	if key == "" {
+		return ""
+	}
+	segments := strings.Split(key, ".")
+	for i := range segments {
+		segments[i] = strings.ReplaceAll(segments[i], "~", "~0")
+		segments[i] = strings.ReplaceAll(segments[i], "/", "~1")
+	}
+	return "/" + strings.Join(segments, "/")
+}
@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/m-row/validator/translations"
+)
+
+func TestNewProblem(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		title  string
+	}{
+		{
+			name:   "malformed",
+			err:    errors.New("unexpected end of json input"),
+			status: http.StatusBadRequest,
+			title:  translations.English{}.BadRequest(),
+		},
+		{
+			name:   "unsupported",
+			err:    &UnsupportedContentTypeError{ContentType: "text/csv"},
+			status: http.StatusUnsupportedMediaType,
+			title:  "the request body has an unsupported media type",
+		},
+		{
+			name:   "too large",
+			err:    &BodyTooLargeError{Limit: 10},
+			status: http.StatusRequestEntityTooLarge,
+			title:  "the request body is too large",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewProblem(translations.English{}, tt.err)
+			if p.Status != tt.status || p.Title != tt.title {
+				t.Fatalf("got %d %q, want %d %q",
+					p.Status, p.Title, tt.status, tt.title)
+			}
+			if p.Detail != tt.err.Error() {
+				t.Fatalf("detail %q, want %q", p.Detail, tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestJSONPointer(t *testing.T) {
+	tests := map[string]string{
+		"":              "",
+		"name":          "/name",
+		"address.city":  "/address/city",
+		"items.0.qty":   "/items/0/qty",
+		"a~b":           "/a~0b",
+		"a/b":           "/a~1b",
+		"header.~/path": "/header/~0~1path",
+	}
+	for key, want := range tests {
+		if got := jsonPointer(key); got != want {
+			t.Errorf("jsonPointer(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestProblemWrite(t *testing.T) {
+	v, err := newTestValidator(t, nil, "application/json", `{"name":""}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v.AssignString("name", nil, 3, 10)
+	w := httptest.NewRecorder()
+	if err := v.WriteProblem(w); err != nil {
+		t.Fatal(err)
+	}
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status %d, want 422", w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != ProblemContentType {
+		t.Fatalf("Content-Type %q, want %q", got, ProblemContentType)
+	}
+	var p Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Status != http.StatusUnprocessableEntity ||
+		len(p.Errors["/name"]) != 1 {
+		t.Fatalf("body %s", w.Body.String())
+	}
+}
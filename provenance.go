@@ -0,0 +1,113 @@
+package validator
+
+// Origin describes where a value in Data was read from. The values of the
+// body have the source of their decoder, SourceJSON, SourceForm or
+// SourceMultipart, or SourceBody for the other media types. Values set by the
+// server through Data.Set, Data.Add or CreateFromMap have no Source.
+type Origin struct {
+	Source Source `json:"source"`
+	// Name is the key as it was sent, before bracketed form keys are
+	// converted to dotted paths: items[0][name], tags[], X-Tenant...
+	Name string `json:"name"`
+	// Index is the position of the value among the values its source
+	// provided for the key.
+	Index int `json:"index"`
+}
+
+// add appends value to key recording name as the key it was sent with.
+func (d *Data) add(key, name, value string) {
+	if d.origins != nil {
+		d.origins[key] = append(d.origins[key], Origin{
+			Source: d.decoder,
+			Name:   name,
+			Index:  len(d.Values[key]),
+		})
+	}
+	d.Values.Add(key, value)
+}
+
+// Origins returns the origin of each value of key, in the same order as
+// Values[key].
+func (d Data) Origins(key string) []Origin {
+	return d.origins[normalizeKey(key)]
+}
+
+// RequireSource adds an error for each key that has a value read from any
+// source other than the allowed ones, so sensitive fields can't be provided
+// through the query string or headers:
+//
+//	v.RequireSource([]string{"role", "price"}, validator.SourceBody)
+//
+// SourceBody allows any body while SourceJSON only allows a json one. Values
+// set by the server are always allowed.
+func (v *Validator) RequireSource(keys []string, allowed ...Source) {
+	for _, key := range keys {
+		for _, origin := range v.Data.Origins(key) {
+			if origin.Source != "" && !origin.Source.within(allowed) {
+				v.CheckCode(
+					false,
+					key,
+					CodeUnsupportedSource,
+					Params{"source": origin.Source},
+					v.T.UnsupportedLocation(string(origin.Source)),
+				)
+				break
+			}
+		}
+	}
+}
+
+// DumpValue is a value of Data with its origin.
+type DumpValue struct {
+	Value string `json:"value"`
+	Origin
+}
+
+// DumpFile is a file of Data with its origin.
+type DumpFile struct {
+	FileName string `json:"file_name"`
+	Size     int64  `json:"size"`
+	Source   Source `json:"source"`
+}
+
+// Dump is a snapshot of the state of a Validator meant for debugging.
+type Dump struct {
+	Values map[string][]DumpValue `json:"values"`
+	Files  map[string][]DumpFile  `json:"files"`
+	Unused []string               `json:"unused"`
+	Scopes []string               `json:"scopes"`
+	Errors Errors                 `json:"errors"`
+}
+
+// Dump returns the values and files of the request with their origins, the
+// keys that weren't assigned yet and the current errors, marshal it to log
+// why a field took an unexpected value.
+func (v *Validator) Dump() *Dump {
+	dump := &Dump{
+		Values: make(map[string][]DumpValue, len(v.Data.Values)),
+		Files:  make(map[string][]DumpFile, len(v.Data.Files)),
+		Unused: v.Data.Unused(v.allowedKeys...),
+		Scopes: v.Scopes,
+		Errors: v.GetErrorMap(),
+	}
+	for key, vals := range v.Data.Values {
+		origins := v.Data.origins[key]
+		for i, val := range vals {
+			value := DumpValue{Value: val}
+			if i < len(origins) {
+				value.Origin = origins[i]
+			}
+			dump.Values[key] = append(dump.Values[key], value)
+		}
+	}
+	for key, files := range v.Data.Files {
+		for _, f := range files {
+			dump.Files[key] = append(dump.Files[key], DumpFile{
+				FileName: f.Filename,
+				Size:     f.Size,
+				Source:   v.Data.sources[key],
+			})
+		}
+	}
+	return dump
+}
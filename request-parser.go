@@ -1,11 +1,14 @@
 package validator
 
 import (
-	"fmt"
+	"bytes"
+	"errors"
 	"io"
+	"mime"
 	"mime/multipart"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -15,7 +18,21 @@ import (
 
 const DefaultMaxFormSize = 16 << 20 // 16mb
 
-// Data holds data obtained from the request body and url query parameters.
+var ErrTrailingJSON = errors.New("unexpected data after json body")
+
+// KeyState is the state of a key in the request, see Data.State.
+type KeyState int
+
+const (
+	KeyAbsent KeyState = iota
+	KeyNull
+	KeyPresent
+)
+
+// Data holds data obtained from the request body and url query parameters,
+// and optionally path parameters, headers and cookies (see Source).
+// Nested json values and bracketed form keys (items[0][name]) are addressed
+// with dotted paths (items.0.name), the same keys GetErrorMap reports.
 // Because Data is built from multiple sources, sometimes there will be more
 // than one value for a given key. You can use Get, Set, Add, and Del to access
 // the first element for a given key or access the Values and Files properties
@@ -30,11 +47,30 @@ type Data struct {
 	Values url.Values
 	// Files holds files from a multipart form only.
 	// For any other type of request, it will always
-	// be empty. Files only supports one file per key,
-	// since this is by far the most common use. If you
-	// need to have more than one file per key, parse the
-	// files manually using req.MultipartForm.File.
-	Files map[string]*multipart.FileHeader
+	// be empty. Every file sent with a key is kept in
+	// the order it was sent, use GetFile for the first
+	// one and GetFiles for all of them.
+	Files map[string][]*multipart.FileHeader
+	// used holds the keys that were read, see Unused.
+	used map[string]struct{}
+	// sources holds the source each key was read from.
+	sources map[string]Source
+	// origins holds the origin of each value in Values.
+	origins map[string][]Origin
+	// decoder is the source recorded in the origin of new values, set by
+	// the decoder of the body.
+	decoder Source
+	// nulls holds the paths that were sent as an explicit json null.
+	nulls map[string]struct{}
+	// jsonStrings holds the paths that were sent as a json string, an
+	// empty one is an explicit value unlike an empty form field.
+	jsonStrings map[string]struct{}
+	// containers holds the element count of json objects and arrays
+	// by their path, see Len.
+	containers map[string]int
+	// next holds the index the next key[] value of a base key takes, so
+	// appending doesn't rescan Values.
+	next map[string]int
 	// jsonBody holds the original body of the request.
 	// Only available for json requests.
 	jsonBody []byte
@@ -42,8 +78,14 @@ type Data struct {
 
 func newData() *Data {
 	return &Data{
-		Values: url.Values{},
-		Files:  map[string]*multipart.FileHeader{},
+		Values:      url.Values{},
+		Files:       map[string][]*multipart.FileHeader{},
+		used:        map[string]struct{}{},
+		sources:     map[string]Source{},
+		origins:     map[string][]Origin{},
+		nulls:       map[string]struct{}{},
+		jsonStrings: map[string]struct{}{},
+		containers:  map[string]int{},
 	}
 }
 
@@ -57,54 +99,74 @@ func (v *Validator) Parse(req *http.Request) error {
 	return nil
 }
 
+// ParseMax reads the sources configured for the validator into Data, by
+// default the request body followed by the url query parameters. A key read
+// from one source is ignored in every later source, so a query parameter
+// can't override a body field with the same name.
+//
+// A body sent with Content-Encoding gzip or deflate is decompressed, see
+// Config.MaxBodySize and Config.MaxDecodedBodySize for its limits.
 func (v *Validator) ParseMax(
 	req *http.Request,
 	maxMemory int64,
 ) (*Data, error) {
 	data := newData()
-	contentType := req.Header.Get("Content-Type")
-	switch contentType {
-	case "multipart/form-data":
-		if err := req.ParseMultipartForm(maxMemory); err != nil {
+	sources := v.sources
+	if len(sources) == 0 {
+		sources = DefaultSources
+	}
+	if hasBody(req) {
+		body, err := v.body(req)
+		if err != nil {
 			return nil, err
 		}
-		for key, vals := range req.MultipartForm.Value {
-			for _, val := range vals {
-				data.Add(key, val)
-			}
-		}
-		for key, files := range req.MultipartForm.File {
-			if len(files) != 0 {
-				data.AddFile(key, files[0])
+		req.Body = body
+	}
+	for _, source := range sources {
+		src, err := v.parseSource(req, source, maxMemory)
+		if err != nil {
+			var tooLarge *BodyTooLargeError
+			if errors.As(err, &tooLarge) {
+				return nil, tooLarge
 			}
-		}
-	case "form-urlencoded":
-		if err := req.ParseForm(); err != nil {
 			return nil, err
 		}
-		for key, vals := range req.PostForm {
-			for _, val := range vals {
-				data.Add(key, val)
-			}
-		}
-	case "application/json":
-		body, err := io.ReadAll(req.Body)
+		data.merge(src, source, sources)
+	}
+	return data, nil
+}
+
+// parseBody parses the request body with the decoder registered for its media
+// type. Requests without a body or without a Content-Type are treated as
+// having no body. Any other media type that can't be decoded results in an
+// *UnsupportedContentTypeError.
+func parseBody(req *http.Request, maxMemory int64) (*Data, error) {
+	data := newData()
+	if !hasBody(req) {
+		return data, nil
+	}
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		mediaType, params, err := mime.ParseMediaType(contentType)
 		if err != nil {
-			return nil, err
+			return nil, &UnsupportedContentTypeError{ContentType: contentType}
 		}
-		data.jsonBody = body
-		if err := parseJSON(data.Values, data.jsonBody); err != nil {
-			return nil, err
+		decoder := lookupDecoder(mediaType)
+		if decoder == nil {
+			return nil, &UnsupportedContentTypeError{ContentType: mediaType}
 		}
-	}
-	for key, vals := range req.URL.Query() {
-		for _, val := range vals {
-			data.Add(key, val)
+		if err := decoder(data, req, params, maxMemory); err != nil {
+			return nil, err
 		}
 	}
 	return data, nil
 }
 
+// hasBody reports whether req was sent with a body, a stray Content-Type on a
+// request without one is ignored.
+func hasBody(req *http.Request) bool {
+	return req.Body != nil && req.Body != http.NoBody && req.ContentLength != 0
+}
+
 // CreateFromMap returns a Data object with keys and values matching
 // the map.
 func CreateFromMap(m map[string]string) *Data {
@@ -115,57 +177,121 @@ func CreateFromMap(m map[string]string) *Data {
 	return data
 }
 
-func parseJSON(values url.Values, body []byte) error {
+func parseJSON(d *Data, body []byte) error {
 	if len(body) == 0 {
 		// don't attempt to parse empty bodies
 		return nil
 	}
-	rawData := map[string]any{}
-	if err := json.Unmarshal(body, &rawData); err != nil {
+	rawData, err := decodeJSONObject(body)
+	if err != nil {
 		return err
 	}
 	// Whatever the underlying type is, we need to convert it to a
-	// string. There are only a few possible types, so we can just
-	// do a type switch over the possibilities.
+	// string. Numbers keep the literal sent by the client. Nested objects
+	// and arrays are kept as a json string at their key and flattened
+	// into dotted paths for their elements.
 	for key, val := range rawData {
-		switch val.(type) {
-		case string, bool, float64:
-			values.Add(key, fmt.Sprint(val))
-		case nil:
-			values.Add(key, "")
-		case map[string]any, []any:
-			// for more complicated data structures, convert back to
-			// a JSON string and let user decide how to unmarshal
-			jsonVal, err := json.Marshal(val)
-			if err != nil {
-				return err
-			}
-			values.Add(key, string(jsonVal))
+		if err := d.AddJSON(key, val); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
+// decodeJSONObject unmarshals body into a map, decoding numbers as
+// json.Number so that large integers and exponents are not rounded through
+// float64.
+func decodeJSONObject(body []byte) (map[string]any, error) {
+	rawData := map[string]any{}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&rawData); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, ErrTrailingJSON
+	}
+	return rawData, nil
+}
+
 // Add adds the value to key.
-// It appends to any existing values associated with key.
+// It appends to any existing values associated with key, a key ending in []
+// appends the value as the next element of the array at key instead.
 func (d *Data) Add(key, value string) {
-	d.Values.Add(key, value)
+	name := key
+	if base, found := strings.CutSuffix(key, "[]"); found {
+		base = normalizeKey(base)
+		if d.next == nil {
+			d.next = map[string]int{}
+		}
+		i, found := d.next[base]
+		if !found {
+			i = d.length(base)
+		}
+		d.next[base] = i + 1
+		key = base + "." + strconv.Itoa(i)
+	}
+	d.add(normalizeKey(key), name, value)
+}
+
+// addValues adds every value of values by key in sorted order, the keys
+// ending in [] last so they follow the elements indexed explicitly.
+func (d *Data) addValues(values map[string][]string) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ai := strings.HasSuffix(keys[i], "[]")
+		aj := strings.HasSuffix(keys[j], "[]")
+		if ai != aj {
+			return aj
+		}
+		return keys[i] < keys[j]
+	})
+	for _, key := range keys {
+		for _, val := range values[key] {
+			d.Add(key, val)
+		}
+	}
 }
 
 // AddFile adds the multipart form file to data with the given key.
+// It appends to any existing files associated with key.
 func (d *Data) AddFile(key string, file *multipart.FileHeader) {
-	d.Files[key] = file
+	key = normalizeKey(key)
+	d.Files[key] = append(d.Files[key], file)
 }
 
-// Del deletes the values associated with key.
+// Del deletes the values associated with key and any path nested under it.
 func (d *Data) Del(key string) {
+	key = normalizeKey(key)
 	d.Values.Del(key)
+	delete(d.origins, key)
+	delete(d.nulls, key)
+	delete(d.jsonStrings, key)
+	delete(d.containers, key)
+	prefix := key + "."
+	for k := range d.next {
+		if k == key || strings.HasPrefix(k, prefix) {
+			delete(d.next, k)
+		}
+	}
+	for k := range d.Values {
+		if strings.HasPrefix(k, prefix) {
+			d.Values.Del(k)
+			delete(d.origins, k)
+			delete(d.nulls, k)
+			delete(d.jsonStrings, k)
+			delete(d.containers, k)
+		}
+	}
 }
 
-// DelFile deletes the file associated with key (if any).
+// DelFile deletes the files associated with key (if any).
 // If there is no file associated with key, it does nothing.
 func (d *Data) DelFile(key string) {
-	delete(d.Files, key)
+	delete(d.Files, normalizeKey(key))
 }
 
 // Encode encodes the values into “URL encoded” form ("bar=baz&foo=quux")
@@ -177,42 +303,117 @@ func (d *Data) Encode() string {
 
 // Get gets the first value associated with the given key. If there are no
 // values associated with the key, Get returns the empty string.
-// To access multiple values, use the map directly.
+// Keys are dotted paths into nested data regardless of how the client
+// encoded it:
+//
+//	d.Get("address.city") // {"address": {"city": "x"}}, address[city]=x
+//	d.Get("items.2.qty")  // {"items": [..., {"qty": 1}]}, items[2][qty]=1
+//
+// A path holding nested values that has no value of its own (as is the case
+// for bracketed form keys) returns them encoded as json.
+// To access multiple values, use GetStrings or the map directly.
 func (d Data) Get(key string) string {
-	return d.Values.Get(key)
+	if vals, found := d.values(key); found {
+		if len(vals) == 0 {
+			return ""
+		}
+		return vals[0]
+	}
+	if d.hasChildren(key) {
+		jsonVal, err := json.Marshal(d.tree(normalizeKey(key)))
+		if err != nil {
+			return ""
+		}
+		return string(jsonVal)
+	}
+	return ""
+}
+
+// GetStrings returns the elements of the array at key, or every value of
+// key when it was repeated in a form or query string.
+func (d Data) GetStrings(key string) []string {
+	key = normalizeKey(key)
+	if segments := d.children(key); len(segments) != 0 && isIndexed(segments) {
+		arr := make([]string, 0, len(segments))
+		for _, s := range segments {
+			arr = append(arr, d.Get(key+"."+s))
+		}
+		return arr
+	}
+	vals, _ := d.values(key)
+	return vals
 }
 
-// GetFile returns the multipart form file associated with key, if any, as a
-// *multipart.FileHeader. If there is no file associated with key, it returns
-// nil. If you just want the body of the file, use GetFileBytes.
+// GetFile returns the first multipart form file associated with key, if any,
+// as a *multipart.FileHeader. If there is no file associated with key, it
+// returns nil. If you just want the body of the file, use GetFileBytes.
 func (d Data) GetFile(key string) *multipart.FileHeader {
+	if files := d.GetFiles(key); len(files) != 0 {
+		return files[0]
+	}
+	return nil
+}
+
+// GetFiles returns every multipart form file associated with key in the order
+// they were sent.
+func (d Data) GetFiles(key string) []*multipart.FileHeader {
+	key = normalizeKey(key)
+	d.use(key)
 	return d.Files[key]
 }
 
 // Set sets the key to value. It replaces any existing values.
 func (d *Data) Set(key, value string) {
+	name := key
+	key = normalizeKey(key)
+	delete(d.nulls, key)
 	d.Values.Set(key, value)
+	if d.origins != nil {
+		d.origins[key] = []Origin{{Name: name}}
+	}
 }
 
-// KeyExists returns true if data.Values[key] exists. When parsing a request
-// body, the key is considered to be in existence if it was provided in the
-// request body, even if its value is empty.
+// KeyExists returns true if key exists in data.Values or is the parent path
+// of nested values. When parsing a request body, the key is considered to be
+// in existence if it was provided in the request body, even if its value is
+// empty.
 func (d Data) KeyExists(key string) bool {
-	_, found := d.Values[key]
+	if _, found := d.values(key); found {
+		return true
+	}
+	return d.hasChildren(key)
+}
+
+// State reports whether key was absent from the request, sent as an explicit
+// json null, or sent with a value (which may be an empty string).
+func (d Data) State(key string) KeyState {
+	if !d.KeyExists(key) {
+		return KeyAbsent
+	}
+	if d.IsNull(key) {
+		return KeyNull
+	}
+	return KeyPresent
+}
+
+// IsNull returns true if key was sent as an explicit json null.
+func (d Data) IsNull(key string) bool {
+	key = normalizeKey(key)
+	d.use(key)
+	_, found := d.nulls[key]
 	return found
 }
 
-// FileExists returns true if data.Files[key] exists. When parsing a request
-// body, the key is considered to be in existence if it was provided in the
-// request body, even if the file is empty.
+// FileExists returns true if data.Files[key] holds at least one file. When
+// parsing a request body, the key is considered to be in existence if it was
+// provided in the request body, even if the file is empty.
 func (d Data) FileExists(key string) bool {
-	_, found := d.Files[key]
-	return found
+	return len(d.GetFiles(key)) != 0
 }
 
 // GetInt returns the first element in data[key] converted to an int.
 func (d Data) GetInt(key string) int {
-	if !d.KeyExists(key) || len(d.Values[key]) == 0 {
+	if !d.KeyExists(key) {
 		return 0
 	}
 	str := d.Get(key)
@@ -225,7 +426,7 @@ func (d Data) GetInt(key string) int {
 
 // GetFloat returns the first element in data[key] converted to a float.
 func (d Data) GetFloat(key string) float64 {
-	if !d.KeyExists(key) || len(d.Values[key]) == 0 {
+	if !d.KeyExists(key) {
 		return 0.0
 	}
 	str := d.Get(key)
@@ -238,7 +439,7 @@ func (d Data) GetFloat(key string) float64 {
 
 // GetBool returns the first element in data[key] converted to a bool.
 func (d Data) GetBool(key string) bool {
-	if !d.KeyExists(key) || len(d.Values[key]) == 0 {
+	if !d.KeyExists(key) {
 		return false
 	}
 	str := d.Get(key)
@@ -251,7 +452,7 @@ func (d Data) GetBool(key string) bool {
 
 // GetUUID returns the first element in data[key] converted to a uuid.
 func (d Data) GetUUID(key string) *uuid.UUID {
-	if !d.KeyExists(key) || len(d.Values[key]) == 0 {
+	if !d.KeyExists(key) {
 		return nil
 	}
 	str := d.Get(key)
@@ -268,39 +469,45 @@ func (d Data) GetBytes(key string) []byte {
 	return []byte(d.Get(key))
 }
 
-// GetFileBytes returns the body of the file associated with key. If there is no
-// file associated with key, it returns nil (not an error). It may return an
-// error if there was a problem reading the file. If you need to know whether or
-// not the file exists (i.e. whether it was provided in the request),
+// GetFileBytes returns the body of the first file associated with key. If
+// there is no file associated with key, it returns nil (not an error). It may
+// return an error if there was a problem reading the file. If you need to know
+// whether or not the file exists (i.e. whether it was provided in the request),
 // use the FileExists method.
 func (d Data) GetFileBytes(key string) ([]byte, error) {
-	fileHeader, found := d.Files[key]
-	if !found {
+	fileHeader := d.GetFile(key)
+	if fileHeader == nil {
 		return nil, nil
-	} else {
-		file, err := fileHeader.Open()
-		if err != nil {
-			return nil, err
-		}
-		return io.ReadAll(file)
 	}
+	return readFileHeader(fileHeader)
+}
+
+// readFileHeader returns the body of a multipart form file.
+func readFileHeader(fileHeader *multipart.FileHeader) ([]byte, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
 }
 
 // GetStringsSplit returns the first element in data[key] split into a slice
 // delimited by delim.
 func (d Data) GetStringsSplit(key, delim string) []string {
-	if !d.KeyExists(key) || len(d.Values[key]) == 0 {
+	if !d.KeyExists(key) {
 		return nil
 	}
-	return strings.Split(d.Values[key][0], delim)
+	return strings.Split(d.Get(key), delim)
 }
 
 // BindJSON binds v to the json data in the request body. It calls
-// json.Unmarshal and sets the value of v.
+// json.Unmarshal and sets the value of v. Every key is then considered used.
 func (d Data) BindJSON(v any) error {
 	if len(d.jsonBody) == 0 {
 		return nil
 	}
+	d.use("")
 	return json.Unmarshal(d.jsonBody, v)
 }
 
@@ -308,11 +515,11 @@ func (d Data) BindJSON(v any) error {
 // attempts to unmarshal it into a map[string]any, and if successful, returns
 // the result. If unmarshaling was not successful, returns an error.
 func (d Data) GetMapFromJSON(key string) (map[string]any, error) {
-	if !d.KeyExists(key) || len(d.Values[key]) == 0 {
+	if !d.KeyExists(key) {
 		return nil, nil
 	}
 	result := map[string]any{}
-	if err := json.Unmarshal([]byte(d.Get(key)), &result); err != nil {
+	if err := json.Unmarshal(d.GetBytes(key), &result); err != nil {
 		return nil, err
 	}
 	return result, nil
@@ -322,11 +529,11 @@ func (d Data) GetMapFromJSON(key string) (map[string]any, error) {
 // string, attempts to unmarshal it into a []any, and if successful, returns
 // the result. If unmarshaling was not successful, returns an error.
 func (d Data) GetSliceFromJSON(key string) ([]any, error) {
-	if !d.KeyExists(key) || len(d.Values[key]) == 0 {
+	if !d.KeyExists(key) {
 		return nil, nil
 	}
 	result := []any{}
-	if err := json.Unmarshal([]byte(d.Get(key)), &result); err != nil {
+	if err := json.Unmarshal(d.GetBytes(key), &result); err != nil {
 		return nil, err
 	}
 	return result, nil
@@ -0,0 +1,196 @@
+package validator
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestValidator parses a request with the given content type and body.
+func newTestValidator(
+	t *testing.T,
+	c *Config,
+	contentType, body string,
+) (*Validator, error) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c == nil {
+		c = &Config{}
+	}
+	c.Request = req
+	return NewValidator(c)
+}
+
+func TestDecodeForm(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		key         string
+		want        string
+	}{
+		{
+			name:        "plain",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "name=ali&age=30",
+			key:         "age",
+			want:        "30",
+		},
+		{
+			name:        "utf-8 charset",
+			contentType: "application/x-www-form-urlencoded; charset=utf-8",
+			body:        "name=%D8%B9%D9%84%D9%8A",
+			key:         "name",
+			want:        "علي",
+		},
+		{
+			name: "latin1 charset",
+			contentType: "application/x-www-form-urlencoded; " +
+				"charset=ISO-8859-1",
+			body: "name=Jos%E9",
+			key:  "name",
+			want: "José",
+		},
+		{
+			name:        "bracketed key",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "address[city]=Tripoli",
+			key:         "address.city",
+			want:        "Tripoli",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := newTestValidator(t, nil, tt.contentType, tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := v.Data.Get(tt.key); got != tt.want {
+				t.Errorf("Get(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeFormDefaultLimit(t *testing.T) {
+	body := "a=" + strings.Repeat("x", DefaultMaxFormBodySize)
+	_, err := newTestValidator(
+		t,
+		nil,
+		"application/x-www-form-urlencoded",
+		body,
+	)
+	var tooLarge *BodyTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("err = %v, want *BodyTooLargeError", err)
+	}
+	if tooLarge.Limit != DefaultMaxFormBodySize {
+		t.Errorf("Limit = %d, want %d", tooLarge.Limit, DefaultMaxFormBodySize)
+	}
+
+	c := &Config{MaxBodySize: 2 * DefaultMaxFormBodySize}
+	_, err = newTestValidator(t, c, "application/x-www-form-urlencoded", body)
+	if err != nil {
+		t.Errorf("err = %v with MaxBodySize set, want nil", err)
+	}
+}
+
+func TestParseUnsupportedContentType(t *testing.T) {
+	_, err := newTestValidator(t, nil, "application/octet-stream", "x")
+	var unsupported *UnsupportedContentTypeError
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("err = %v, want *UnsupportedContentTypeError", err)
+	}
+}
+
+func TestParseWithoutBody(t *testing.T) {
+	for _, body := range []io.Reader{nil, http.NoBody, strings.NewReader("")} {
+		req := httptest.NewRequest(http.MethodGet, "/?a=1", body)
+		req.Header.Set("Content-Type", "text/plain")
+		v, err := NewValidator(&Config{Request: req})
+		if err != nil {
+			t.Fatalf("body %T: %v", body, err)
+		}
+		if got := v.Data.Get("a"); got != "1" {
+			t.Fatalf("body %T: a = %q, want 1", body, got)
+		}
+	}
+}
+
+func TestAddIndexesBracketKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		key  string
+		want string
+		len  int
+	}{
+		{
+			name: "appended in order",
+			body: "tags[]=a&tags[]=b&tags[]=c",
+			key:  "tags.2",
+			want: "c",
+			len:  3,
+		},
+		{
+			name: "nested base",
+			body: "item[tags][]=a&item[tags][]=b",
+			key:  "item.tags.1",
+			want: "b",
+			len:  2,
+		},
+		{
+			name: "after explicit indexes",
+			body: "tags[0]=a&tags[1]=b&tags[]=c",
+			key:  "tags.2",
+			want: "c",
+			len:  3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := newTestValidator(
+				t,
+				nil,
+				"application/x-www-form-urlencoded",
+				tt.body,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := v.Data.Get(tt.key); got != tt.want {
+				t.Errorf("Get(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+			base, _ := splitParent(tt.key)
+			if got := v.Data.Len(base); got != tt.len {
+				t.Errorf("Len(%q) = %d, want %d", base, got, tt.len)
+			}
+		})
+	}
+}
+
+func TestAddBracketKeysAfterDel(t *testing.T) {
+	d := newData()
+	d.Add("tags[]", "a")
+	d.Add("tags[]", "b")
+	d.Del("tags")
+	d.Add("tags[]", "c")
+	if got := d.Get("tags.0"); got != "c" {
+		t.Errorf("Get(tags.0) = %q, want c", got)
+	}
+}
+
+func BenchmarkAddBracketKeys(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		d := newData()
+		for j := 0; j < 20000; j++ {
+			d.Add("tags[]", "x")
+		}
+	}
+}
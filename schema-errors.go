@@ -0,0 +1,209 @@
+package validator
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/m-row/validator/interfaces"
+	js "github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+var (
+	schemaMissingRe = regexp.MustCompile(`'((?:[^'\\]|\\.)*)'`)
+	schemaLengthRe  = regexp.MustCompile(`length must be [<>]= (\d+)`)
+	schemaBoundRe   = regexp.MustCompile(`must be [<>]=? (\S+)`)
+	schemaItemsRe   = regexp.MustCompile(`(?:minimum|maximum) (\d+) items`)
+	schemaFormatRe  = regexp.MustCompile(`is not valid '([^']*)'$`)
+	schemaTypeRe    = regexp.MustCompile(`^expected ([a-z]+)`)
+	schemaPatternRe = regexp.MustCompile(`^does not match pattern '(.*)'$`)
+)
+
+// addSchemaError adds every failed keyword of a json schema validation error
+// as a cause with its code and params, replacing the english message of the
+// jsonschema library by the matching Translation message. A required failure
+// is added once per missing property, at the property itself.
+func (v *Validator) addSchemaError(err *js.ValidationError) {
+	if len(err.Causes) != 0 {
+		for _, cause := range err.Causes {
+			v.addSchemaError(cause)
+		}
+		return
+	}
+	keyword := err.KeywordLocation
+	if i := strings.LastIndexByte(keyword, '/'); i >= 0 {
+		keyword = keyword[i+1:]
+	}
+	if keyword == "required" {
+		for _, match := range schemaMissingRe.FindAllStringSubmatch(
+			err.Message,
+			-1,
+		) {
+			property := strings.ReplaceAll(match[1], `\'`, `'`)
+			v.addCause(&js.ValidationError{
+				KeywordLocation:         err.KeywordLocation,
+				AbsoluteKeywordLocation: err.AbsoluteKeywordLocation,
+				InstanceLocation:        err.InstanceLocation + "/" + property,
+				Message:                 v.T.ValidateRequired(),
+				Causes:                  []*js.ValidationError{},
+			}, CodeRequired, nil)
+		}
+		return
+	}
+	code, params, message := v.schemaMessage(keyword, err.Message)
+	v.addCause(&js.ValidationError{
+		KeywordLocation:         err.KeywordLocation,
+		AbsoluteKeywordLocation: err.AbsoluteKeywordLocation,
+		InstanceLocation:        err.InstanceLocation,
+		Message:                 message,
+		Causes:                  []*js.ValidationError{},
+	}, code, params)
+}
+
+// schemaMessage returns the code, params and translated message of a failed
+// schema keyword. Keywords without a matching Translation method are
+// translated by an interfaces.SchemaTranslation, if implemented, otherwise
+// the original message is kept.
+func (v *Validator) schemaMessage(
+	keyword, message string,
+) (string, Params, string) {
+	switch keyword {
+	case "minLength", "maxLength":
+		if n, ok := submatchInt(schemaLengthRe, message); ok {
+			if keyword == "minLength" {
+				return CodeMinLength, Params{"min": n}, v.T.ValidateMinChar(n)
+			}
+			return CodeMaxLength, Params{"max": n}, v.T.ValidateMaxChar(n)
+		}
+	case "minimum", "exclusiveMinimum":
+		if f, ok := submatchFloat(schemaBoundRe, message); ok {
+			params := Params{"min": f}
+			switch {
+			case keyword == "minimum" && f == 0:
+				return CodeMin, params, v.T.ValidateMustBeGteZero()
+			case keyword == "minimum":
+				return CodeMin, params, v.T.ValidateMustBeGteFloatValue(f)
+			case f == 0:
+				return CodeMin, params, v.T.ValidateMustBeGtZero()
+			}
+			return v.schemaFallback(keyword, CodeMin, params, message)
+		}
+	case "maximum", "exclusiveMaximum":
+		if f, ok := submatchFloat(schemaBoundRe, message); ok {
+			params := Params{"max": f}
+			if keyword == "maximum" && f == float64(int(f)) {
+				return CodeMax, params, v.T.ValidateMustBeLteValue(int(f))
+			}
+			return v.schemaFallback(keyword, CodeMax, params, message)
+		}
+	case "minItems":
+		if n, ok := submatchInt(schemaItemsRe, message); ok {
+			if n == 1 {
+				return CodeRequiredArray,
+					Params{"min": n},
+					v.T.ValidateRequiredArray()
+			}
+			return v.schemaFallback(keyword, CodeMin, Params{"min": n}, message)
+		}
+	case "maxItems":
+		if n, ok := submatchInt(schemaItemsRe, message); ok {
+			return v.schemaFallback(keyword, CodeMax, Params{"max": n}, message)
+		}
+	case "enum", "const":
+		list := schemaEnumList(message)
+		if list != nil {
+			return CodeEnum,
+				Params{"allowed": list},
+				v.T.ValidateMustBeInList(&list)
+		}
+		return v.schemaFallback(keyword, CodeEnum, nil, message)
+	case "format":
+		if match := schemaFormatRe.FindStringSubmatch(message); match != nil {
+			params := Params{"format": match[1]}
+			switch match[1] {
+			case "email", "idn-email":
+				return CodeFormat, params, v.T.ValidateEmail()
+			case "uuid":
+				return CodeFormat, params, v.T.ValidateUUID()
+			case "date", "date-time":
+				return CodeFormat, params, v.T.ValidateDate()
+			}
+			return v.schemaFallback(keyword, CodeFormat, params, message)
+		}
+	case "type":
+		if match := schemaTypeRe.FindStringSubmatch(message); match != nil {
+			params := Params{"type": match[1]}
+			switch match[1] {
+			case "integer":
+				return CodeType, params, v.T.ValidateInt()
+			case "number":
+				return CodeType, params, v.T.ValidateRequiredFloat()
+			case "boolean":
+				return CodeType, params, v.T.ValidateBool()
+			case "array":
+				return CodeType, params, v.T.ValidateRequiredArray()
+			}
+			return v.schemaFallback(keyword, CodeType, params, message)
+		}
+	case "pattern":
+		var params Params
+		if match := schemaPatternRe.FindStringSubmatch(message); match != nil {
+			params = Params{"pattern": match[1]}
+		}
+		return v.schemaFallback(keyword, CodePattern, params, message)
+	}
+	if keyword == "" {
+		keyword = CodeSchema
+	}
+	return v.schemaFallback(keyword, keyword, nil, message)
+}
+
+// schemaFallback translates keyword through an interfaces.SchemaTranslation
+// when T implements it, otherwise it keeps the original message.
+func (v *Validator) schemaFallback(
+	keyword, code string,
+	params Params,
+	message string,
+) (string, Params, string) {
+	if t, ok := v.T.(interfaces.SchemaTranslation); ok {
+		return code, params, t.ValidateSchemaKeyword(keyword, params)
+	}
+	return code, params, message
+}
+
+func submatchInt(re *regexp.Regexp, message string) (int, bool) {
+	match := re.FindStringSubmatch(message)
+	if match == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(match[1])
+	return n, err == nil
+}
+
+func submatchFloat(re *regexp.Regexp, message string) (float64, bool) {
+	match := re.FindStringSubmatch(message)
+	if match == nil {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(match[1], 64)
+	return f, err == nil
+}
+
+// schemaEnumList parses the allowed values of an enum or const failure,
+// formatted as: value must be one of "a", "b"
+func schemaEnumList(message string) []string {
+	values, found := strings.CutPrefix(message, "value must be one of ")
+	if !found {
+		values, found = strings.CutPrefix(message, "value must be ")
+		if !found {
+			return nil
+		}
+	}
+	list := strings.Split(values, ", ")
+	for i := range list {
+		if s, err := strconv.Unquote(list[i]); err == nil {
+			list[i] = s
+		}
+	}
+	return list
+}
@@ -0,0 +1,207 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/m-row/validator/interfaces"
+	"github.com/m-row/validator/translations"
+	js "github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// TestSchemaMessages pins the messages of the jsonschema library that
+// schemaMessage parses, an upgrade changing them fails here rather than
+// silently dropping the params.
+func TestSchemaMessages(t *testing.T) {
+	tests := []struct {
+		schema   string
+		instance string
+		raw      string
+		code     string
+		params   Params
+		message  string
+	}{
+		{
+			schema:   `{"minLength":3}`,
+			instance: `"ab"`,
+			raw:      "length must be >= 3, but got 2",
+			code:     CodeMinLength,
+			params:   Params{"min": 3},
+			message:  "must be at least 3 characters",
+		},
+		{
+			schema:   `{"maxLength":3}`,
+			instance: `"abcd"`,
+			raw:      "length must be <= 3, but got 4",
+			code:     CodeMaxLength,
+			params:   Params{"max": 3},
+			message:  "must be at most 3 characters",
+		},
+		{
+			schema:   `{"minimum":2.5}`,
+			instance: `1`,
+			raw:      "must be >= 2.5 but found 1",
+			code:     CodeMin,
+			params:   Params{"min": 2.5},
+			message:  "must be greater than or equal to 2.5",
+		},
+		{
+			schema:   `{"exclusiveMinimum":2}`,
+			instance: `1`,
+			raw:      "must be > 2 but found 1",
+			code:     CodeMin,
+			params:   Params{"min": 2.0},
+			message:  "must be greater than 2",
+		},
+		{
+			schema:   `{"maximum":2.5}`,
+			instance: `3`,
+			raw:      "must be <= 2.5 but found 3",
+			code:     CodeMax,
+			params:   Params{"max": 2.5},
+			message:  "must be at most 2.5",
+		},
+		{
+			schema:   `{"exclusiveMaximum":10}`,
+			instance: `10`,
+			raw:      "must be < 10 but found 10",
+			code:     CodeMax,
+			params:   Params{"max": 10.0},
+			message:  "must be less than 10",
+		},
+		{
+			schema:   `{"minItems":2}`,
+			instance: `[1]`,
+			raw:      "minimum 2 items required, but found 1 items",
+			code:     CodeMin,
+			params:   Params{"min": 2},
+			message:  "must have at least 2 items",
+		},
+		{
+			schema:   `{"maxItems":1}`,
+			instance: `[1,2]`,
+			raw:      "maximum 1 items required, but found 2 items",
+			code:     CodeMax,
+			params:   Params{"max": 1},
+		},
+		{
+			schema:   `{"enum":["a","b"]}`,
+			instance: `"c"`,
+			raw:      `value must be one of "a", "b"`,
+			code:     CodeEnum,
+			params:   Params{"allowed": []string{"a", "b"}},
+		},
+		{
+			schema:   `{"const":"a"}`,
+			instance: `"c"`,
+			raw:      `value must be "a"`,
+			code:     CodeEnum,
+			params:   Params{"allowed": []string{"a"}},
+		},
+		{
+			schema:   `{"format":"ipv4"}`,
+			instance: `"x"`,
+			raw:      "'x' is not valid 'ipv4'",
+			code:     CodeFormat,
+			params:   Params{"format": "ipv4"},
+			message:  "must be a valid ipv4",
+		},
+		{
+			schema:   `{"type":"string"}`,
+			instance: `1`,
+			raw:      "expected string, but got number",
+			code:     CodeType,
+			params:   Params{"type": "string"},
+			message:  "must be of type string",
+		},
+		{
+			schema:   `{"pattern":"^[a-z]+$"}`,
+			instance: `"1"`,
+			raw:      "does not match pattern '^[a-z]+$'",
+			code:     CodePattern,
+			params:   Params{"pattern": "^[a-z]+$"},
+			message:  "has an invalid format",
+		},
+	}
+	for _, tt := range tests {
+		name := strings.Trim(tt.schema, "{}")
+		t.Run(name, func(t *testing.T) {
+			leaf := schemaFailure(t, tt.schema, tt.instance)
+			if leaf.Message != tt.raw {
+				t.Fatalf("jsonschema message %q, want %q", leaf.Message, tt.raw)
+			}
+			v, err := newTestValidator(t, nil, "application/json", `{}`)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v.addSchemaError(leaf)
+			details := v.GetErrorDetails()[""]
+			if len(details) != 1 {
+				t.Fatalf("details %v", v.GetErrorDetails())
+			}
+			got := details[0]
+			if got.Code != tt.code ||
+				!reflect.DeepEqual(got.Params, tt.params) {
+				t.Fatalf("got %s %v, want %s %v",
+					got.Code, got.Params, tt.code, tt.params)
+			}
+			if tt.message != "" && got.Message != tt.message {
+				t.Fatalf("message %q, want %q", got.Message, tt.message)
+			}
+		})
+	}
+}
+
+// schemaFailure returns the single failed keyword of instance against schema,
+// formats are asserted.
+func schemaFailure(t *testing.T, schema, instance string) *js.ValidationError {
+	t.Helper()
+	c := js.NewCompiler()
+	c.AssertFormat = true
+	err := c.AddResource("schema.json", strings.NewReader(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := c.Compile("schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var val any
+	if err := json.Unmarshal([]byte(instance), &val); err != nil {
+		t.Fatal(err)
+	}
+	var verr *js.ValidationError
+	if !errors.As(s.Validate(val), &verr) {
+		t.Fatalf("%s is valid against %s", instance, schema)
+	}
+	for len(verr.Causes) == 1 {
+		verr = verr.Causes[0]
+	}
+	return verr
+}
+
+// TestSchemaPatternTranslated checks the built-in translations word pattern
+// failures through SchemaTranslation instead of the library message.
+func TestSchemaPatternTranslated(t *testing.T) {
+	leaf := schemaFailure(t, `{"pattern":"^[a-z]+$"}`, `"1"`)
+	for _, tr := range []interfaces.Translation{
+		translations.English{},
+		translations.Arabic{},
+	} {
+		v, err := newTestValidator(t, &Config{T: tr}, "application/json", `{}`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v.addSchemaError(leaf)
+		want := tr.(interfaces.SchemaTranslation).ValidateSchemaKeyword(
+			"pattern",
+			nil,
+		)
+		if got := v.GetErrorDetails()[""][0].Message; got != want {
+			t.Errorf("%T: got %q, want %q", tr, got, want)
+		}
+	}
+}
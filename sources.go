@@ -0,0 +1,186 @@
+package validator
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Source is where a value in Data was read from.
+type Source string
+
+const (
+	SourceBody   Source = "body"
+	SourceQuery  Source = "query"
+	SourcePath   Source = "path"
+	SourceHeader Source = "header"
+	SourceCookie Source = "cookie"
+)
+
+// SourceJSON, SourceForm and SourceMultipart tell apart the values of a body
+// by the decoder that read them, see Origin. They're part of SourceBody and
+// can't be listed in Config.Sources.
+const (
+	SourceJSON      Source = "json"
+	SourceForm      Source = "form"
+	SourceMultipart Source = "multipart"
+)
+
+// within reports whether s is one of sources, a value of the body is within
+// SourceBody as well as its own source.
+func (s Source) within(sources []Source) bool {
+	if slices.Contains(sources, s) {
+		return true
+	}
+	switch s {
+	case SourceJSON, SourceForm, SourceMultipart:
+		return slices.Contains(sources, SourceBody)
+	}
+	return false
+}
+
+// DefaultSources are read when Config.Sources is empty.
+var DefaultSources = []Source{SourceBody, SourceQuery}
+
+// parseSource reads a single source of the request into a new Data. Path
+// values, headers and cookies are only read when named in the config and are
+// stored under their source as a namespace:
+//
+//	path.id
+//	header.Idempotency-Key
+//	cookie.session
+//
+// While their source is read, these namespaces are reserved and the body and
+// query can't provide a key under them. Otherwise a body field named path,
+// header or cookie is kept like any other.
+func (v *Validator) parseSource(
+	req *http.Request,
+	source Source,
+	maxMemory int64,
+) (*Data, error) {
+	switch source {
+	case SourceBody:
+		return parseBody(req, maxMemory)
+	case SourceQuery:
+		data := newData()
+		data.addValues(req.URL.Query())
+		return data, nil
+	case SourcePath:
+		data := newData()
+		for _, name := range v.pathValues {
+			if val := req.PathValue(name); val != "" {
+				data.addNamespaced(SourcePath, name, val)
+			}
+		}
+		return data, nil
+	case SourceHeader:
+		data := newData()
+		for _, name := range v.headers {
+			for _, val := range req.Header.Values(name) {
+				data.addNamespaced(SourceHeader, name, val)
+			}
+		}
+		return data, nil
+	case SourceCookie:
+		data := newData()
+		for _, name := range v.cookies {
+			if cookie, err := req.Cookie(name); err == nil {
+				data.addNamespaced(SourceCookie, name, cookie.Value)
+			}
+		}
+		return data, nil
+	default:
+		return nil, &UnsupportedSourceError{Source: source}
+	}
+}
+
+// addNamespaced adds value to the key name under the namespace of source.
+func (d *Data) addNamespaced(source Source, name, value string) {
+	d.add(normalizeKey(string(source)+"."+name), name, value)
+}
+
+// UnsupportedSourceError is returned by ParseMax for an unknown Source.
+type UnsupportedSourceError struct {
+	Source Source
+}
+
+func (e *UnsupportedSourceError) Error() string {
+	return "unsupported source: " + string(e.Source)
+}
+
+// merge adds the values and files of src read from source to d, skipping
+// every key whose root was already provided by an earlier source or is the
+// namespace of another of the read sources, so a body field can't pose as a
+// header.
+func (d *Data) merge(src *Data, source Source, sources []Source) {
+	provided := map[string]struct{}{}
+	for key := range d.sources {
+		provided[rootKey(key)] = struct{}{}
+	}
+	for key, vals := range src.Values {
+		_, found := provided[rootKey(key)]
+		if found || reserved(key, source, sources) {
+			continue
+		}
+		d.Values[key] = append(d.Values[key], vals...)
+		d.sources[key] = source
+		for _, origin := range src.origins[key] {
+			if origin.Source == "" {
+				origin.Source = source
+			}
+			d.origins[key] = append(d.origins[key], origin)
+		}
+		if _, found := src.nulls[key]; found {
+			d.nulls[key] = struct{}{}
+		}
+		if _, found := src.jsonStrings[key]; found {
+			d.jsonStrings[key] = struct{}{}
+		}
+		if n, found := src.containers[key]; found {
+			d.containers[key] = n
+		}
+	}
+	for key, files := range src.Files {
+		_, found := provided[rootKey(key)]
+		if found || reserved(key, source, sources) {
+			continue
+		}
+		d.Files[key] = append(d.Files[key], files...)
+		d.sources[key] = source
+	}
+	if d.jsonBody == nil {
+		d.jsonBody = src.jsonBody
+	}
+}
+
+// reserved reports whether key lies under the namespace of one of sources
+// other than source, see parseSource.
+func reserved(key string, source Source, sources []Source) bool {
+	switch root := Source(rootKey(key)); root {
+	case SourcePath, SourceHeader, SourceCookie:
+		return root != source && slices.Contains(sources, root)
+	}
+	return false
+}
+
+// rootKey returns the first segment of a dotted path.
+func rootKey(key string) string {
+	root, _, _ := strings.Cut(key, ".")
+	return root
+}
+
+// Source returns the source key was read from, or an empty Source if it
+// wasn't read from the request.
+func (d Data) Source(key string) Source {
+	key = normalizeKey(key)
+	for {
+		if source, found := d.sources[key]; found {
+			return source
+		}
+		parent, _ := splitParent(key)
+		if parent == "" {
+			return ""
+		}
+		key = parent
+	}
+}
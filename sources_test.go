@@ -0,0 +1,230 @@
+package validator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestSources(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		header string
+		key    string
+		want   string
+		source Source
+	}{
+		{
+			name:   "body wins over query",
+			target: "/?name=query",
+			body:   `{"name":"body"}`,
+			key:    "name",
+			want:   "body",
+			source: SourceBody,
+		},
+		{
+			name:   "query only",
+			target: "/?page=2",
+			body:   `{"name":"body"}`,
+			key:    "page",
+			want:   "2",
+			source: SourceQuery,
+		},
+		{
+			name:   "header",
+			target: "/",
+			body:   `{}`,
+			header: "real",
+			key:    "header.X-Token",
+			want:   "real",
+			source: SourceHeader,
+		},
+		{
+			name:   "header spoofed by body",
+			target: "/",
+			body:   `{"header":{"X-Token":"spoof"}}`,
+			key:    "header.X-Token",
+		},
+		{
+			name:   "header spoofed by query",
+			target: "/?header.X-Token=spoof",
+			body:   `{}`,
+			key:    "header.X-Token",
+		},
+		{
+			name:   "header spoofed by bracketed query",
+			target: "/?header[X-Token]=spoof",
+			body:   `{}`,
+			header: "real",
+			key:    "header.X-Token",
+			want:   "real",
+			source: SourceHeader,
+		},
+		{
+			name:   "cookie spoofed by body",
+			target: "/",
+			body:   `{"cookie.session":"spoof"}`,
+			key:    "cookie.session",
+		},
+		{
+			name:   "path spoofed by query",
+			target: "/?path.id=spoof",
+			body:   `{}`,
+			key:    "path.id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(
+				http.MethodPost,
+				tt.target,
+				strings.NewReader(tt.body),
+			)
+			req.Header.Set("Content-Type", "application/json")
+			if tt.header != "" {
+				req.Header.Set("X-Token", tt.header)
+			}
+			v, err := NewValidator(&Config{
+				Request: req,
+				Sources: []Source{
+					SourceBody,
+					SourceQuery,
+					SourcePath,
+					SourceHeader,
+					SourceCookie,
+				},
+				PathValues: []string{"id"},
+				Headers:    []string{"X-Token"},
+				Cookies:    []string{"session"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := v.Data.Get(tt.key); got != tt.want {
+				t.Fatalf("Get(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+			if got := v.Data.Source(tt.key); got != tt.source {
+				t.Fatalf("Source(%q) = %q, want %q", tt.key, got, tt.source)
+			}
+		})
+	}
+}
+
+// TestSourcesNotRead checks the namespaces of sources that aren't read are
+// ordinary body keys.
+func TestSourcesNotRead(t *testing.T) {
+	v, err := newTestValidator(
+		t,
+		&Config{Sources: []Source{SourceBody, SourceHeader}},
+		"application/json",
+		`{"path":"/img/a.png","header":{"title":"Hi"},"cookie":"c"}`,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"path":         "/img/a.png",
+		"header.title": "",
+		"cookie":       "c",
+	}
+	for key, val := range want {
+		if got := v.Data.Get(key); got != val {
+			t.Errorf("Get(%q) = %q, want %q", key, got, val)
+		}
+	}
+
+	v, err = newTestValidator(
+		t,
+		nil,
+		"application/json",
+		`{"path":"/img/a.png","header":{"title":"Hi"}}`,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.Data.Get("path"); got != "/img/a.png" {
+		t.Errorf("Get(path) = %q with the default sources", got)
+	}
+	if got := v.Data.Get("header.title"); got != "Hi" {
+		t.Errorf("Get(header.title) = %q with the default sources", got)
+	}
+}
+
+func TestOriginSource(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		source      Source
+	}{
+		{
+			name:        "json",
+			contentType: "application/json",
+			body:        `{"role":"admin"}`,
+			source:      SourceJSON,
+		},
+		{
+			name:        "form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "role=admin",
+			source:      SourceForm,
+		},
+		{
+			name:        "multipart",
+			contentType: "multipart/form-data; boundary=b",
+			body: "--b\r\n" +
+				"Content-Disposition: form-data; name=\"role\"\r\n\r\n" +
+				"admin\r\n--b--\r\n",
+			source: SourceMultipart,
+		},
+		{
+			name:        "xml",
+			contentType: "application/xml",
+			body:        "<user><role>admin</role></user>",
+			source:      SourceBody,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := newTestValidator(t, nil, tt.contentType, tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			origins := v.Data.Origins("role")
+			if len(origins) != 1 || origins[0].Source != tt.source {
+				t.Fatalf("origins %+v, want source %q", origins, tt.source)
+			}
+			v.RequireSource([]string{"role"}, SourceBody)
+			if !v.Valid() {
+				t.Fatalf("body required: %v", v.GetErrorMap())
+			}
+			v.RequireSource([]string{"role"}, SourceJSON)
+			if v.Valid() != (tt.source == SourceJSON) {
+				t.Fatalf("json required: %v", v.GetErrorMap())
+			}
+		})
+	}
+}
+
+func TestRequireSourceServerSet(t *testing.T) {
+	v, err := newTestValidator(t, nil, "application/json", `{"name":"x"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v.Data.Set("role", "admin")
+	v.Data.Add("tags[]", "a")
+	v.RequireSource([]string{"role", "tags.0"}, SourceBody)
+	if !v.Valid() {
+		t.Fatalf("server values rejected: %v", v.GetErrorMap())
+	}
+
+	d := &Data{Values: url.Values{}}
+	d.Set("role", "admin")
+	if got := d.Get("role"); got != "admin" {
+		t.Fatalf("Get(role) = %q on a Data literal", got)
+	}
+}
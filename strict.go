@@ -0,0 +1,116 @@
+package validator
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	js "github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// use marks key as read, an empty key marks every key.
+func (d Data) use(key string) {
+	if d.used != nil {
+		d.used[key] = struct{}{}
+	}
+}
+
+// isUsed reports whether key, one of its parents or one of its nested paths
+// was read.
+func (d Data) isUsed(key string) bool {
+	if _, found := d.used[""]; found {
+		return true
+	}
+	for k := range d.used {
+		if k == key ||
+			strings.HasPrefix(key, k+".") ||
+			strings.HasPrefix(k, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Unused returns the sorted keys of Values and Files that were never read,
+// ignoring the allowed keys and the paths nested under them. Path values,
+// headers and cookies are never reported since they are read by name.
+// A key nested under an unused key is not reported on its own.
+func (d Data) Unused(allowed ...string) []string {
+	unused := map[string]struct{}{}
+	collect := func(key string) {
+		switch d.Source(key) {
+		case SourcePath, SourceHeader, SourceCookie:
+			return
+		}
+		for _, a := range allowed {
+			a = normalizeKey(a)
+			if key == a || strings.HasPrefix(key, a+".") {
+				return
+			}
+		}
+		if !d.isUsed(key) {
+			unused[key] = struct{}{}
+		}
+	}
+	for key := range d.Values {
+		collect(key)
+	}
+	for key := range d.Files {
+		collect(key)
+	}
+	keys := []string{}
+	for key := range unused {
+		if parent, _ := splitParent(key); parent != "" {
+			if _, found := unused[parent]; found {
+				continue
+			}
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// RejectUnknownKeys adds an error for every key of the request that wasn't
+// read by an Assign helper, UnmarshalInto, ValidatePropertySchema or any other
+// access to Data, except for the keys allowed in Config.AllowedKeys.
+//
+// Each call replaces the errors of the previous one, so a key assigned since
+// is no longer rejected. In strict mode every call to Valid calls it.
+func (v *Validator) RejectUnknownKeys() {
+	v.dropUnknownKeys()
+	message := v.Message(CodeUnknownKey, nil, "unknown field")
+	unknown := []*js.ValidationError{}
+	for _, key := range v.Data.Unused(v.allowedKeys...) {
+		cause := &js.ValidationError{
+			InstanceLocation: key,
+			Message:          message,
+			Causes:           []*js.ValidationError{},
+		}
+		v.addCause(cause, CodeUnknownKey, nil)
+		unknown = append(unknown, cause)
+	}
+	v.unknownKeys = unknown
+}
+
+// dropUnknownKeys removes the causes added by the last RejectUnknownKeys.
+func (v *Validator) dropUnknownKeys() {
+	if len(v.unknownKeys) == 0 {
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	drop := make(map[*js.ValidationError]struct{}, len(v.unknownKeys))
+	for _, cause := range v.unknownKeys {
+		drop[cause] = struct{}{}
+		delete(v.details, cause)
+	}
+	v.Error.Causes = slices.DeleteFunc(
+		v.Error.Causes,
+		func(cause *js.ValidationError) bool {
+			_, found := drop[cause]
+			return found
+		},
+	)
+	v.unknownKeys = nil
+}
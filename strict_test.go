@@ -0,0 +1,132 @@
+package validator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/m-row/validator/translations"
+)
+
+func TestStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		allowed []string
+		assign  []string
+		unknown []string
+	}{
+		{
+			name:   "every key assigned",
+			body:   `{"name":"a","title":"b"}`,
+			assign: []string{"name", "title"},
+		},
+		{
+			name:    "unknown key",
+			body:    `{"name":"a","title":"b"}`,
+			assign:  []string{"name"},
+			unknown: []string{"title"},
+		},
+		{
+			name:    "allowed key",
+			body:    `{"name":"a","page":"2"}`,
+			allowed: []string{"page"},
+			assign:  []string{"name"},
+		},
+		{
+			name:    "nested unknown keys reported once",
+			body:    `{"name":"a","meta":{"a":1,"b":2}}`,
+			assign:  []string{"name"},
+			unknown: []string{"meta"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := newTestValidator(
+				t,
+				&Config{Strict: true, AllowedKeys: tt.allowed},
+				"application/json",
+				tt.body,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, key := range tt.assign {
+				var s *string
+				v.AssignString(key, s, 0, 10)
+			}
+			// calling Valid again must not add the errors twice
+			for range 2 {
+				if valid := v.Valid(); valid != (len(tt.unknown) == 0) {
+					t.Fatalf("Valid() = %t, errors %v", valid, v.GetErrorMap())
+				}
+			}
+			details := v.GetErrorDetails()
+			keys := make([]string, 0, len(details))
+			for key, d := range details {
+				if len(d) != 1 || d[0].Code != CodeUnknownKey ||
+					d[0].Message != "unknown field" {
+					t.Fatalf("details of %s: %+v", key, d)
+				}
+				keys = append(keys, key)
+			}
+			slices.Sort(keys)
+			if !slices.Equal(keys, tt.unknown) {
+				t.Fatalf("unknown keys %v, want %v", keys, tt.unknown)
+			}
+		})
+	}
+}
+
+func TestStrictAssignedAfterValid(t *testing.T) {
+	v, err := newTestValidator(
+		t,
+		&Config{Strict: true},
+		"application/json",
+		`{"name":"a","title":"b"}`,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var name, title *string
+	v.AssignString("name", name, 0, 10)
+	if v.Valid() {
+		t.Fatal("Valid() = true with title unassigned")
+	}
+	v.AssignString("title", title, 0, 10)
+	if !v.Valid() {
+		t.Fatalf("Valid() = false, errors %v", v.GetErrorMap())
+	}
+}
+
+func TestStrictLangParam(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		valid  bool
+	}{
+		{
+			name:  "negotiated",
+			valid: true,
+		},
+		{
+			name:   "explicit translation",
+			config: Config{T: translations.English{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.config
+			c.Strict = true
+			c.Request = httptest.NewRequest(http.MethodGet, "/?lang=ar", nil)
+			v, err := NewValidator(&c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v.Valid() != tt.valid {
+				t.Fatalf("Valid() = %t, errors %v", !tt.valid, v.GetErrorMap())
+			}
+		})
+	}
+}
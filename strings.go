@@ -45,9 +45,13 @@ func startsWithLetter(s string) bool {
 //
 //	v.AssignString("name", &m.Name, "vendor", "driver")
 //
-// nullable strings must be assigned back:
+// nullable strings must be assigned back, a json null then clears them
+// unless the key is marked with ForbidNull:
 //
 //	m.Name = v.AssignString("name", m.Name)
+//
+// an empty string sent in a json body is assigned and checked against
+// minlength, an empty form field is skipped.
 func (v *Validator) AssignString(
 	key string,
 	property *string,
@@ -56,24 +60,38 @@ func (v *Validator) AssignString(
 ) *string {
 	if v.Data.KeyExists(key) {
 		v.Permit(key, allowedScopes)
-		if val := v.Data.Values.Get(key); val != "" {
-			if property == nil {
-				property = new(string)
-			}
-			// removes consecutive white spaces in between
-			// + leading and trailing
-			*property = strings.Join(
-				strings.Fields(strings.TrimSpace(val)),
-				" ",
+		if v.Data.IsNull(key) {
+			return assignNull(v, key, property)
+		}
+		val, ok := v.Data.assigned(key)
+		if !ok {
+			return property
+		}
+		if property == nil {
+			property = new(string)
+		}
+		// removes consecutive white spaces in between
+		// + leading and trailing
+		*property = strings.Join(strings.Fields(strings.TrimSpace(val)), " ")
+		if len(*property) < minlength {
+			v.CheckCode(
+				false,
+				key,
+				CodeMinLength,
+				Params{"min": minlength},
+				v.T.ValidateMinChar(minlength),
 			)
-			if len(*property) < minlength {
-				v.Check(false, key, v.T.ValidateMinChar(minlength))
-				return nil
-			}
-			if len(*property) > maxlength {
-				v.Check(false, key, v.T.ValidateMaxChar(maxlength))
-				return nil
-			}
+			return nil
+		}
+		if len(*property) > maxlength {
+			v.CheckCode(
+				false,
+				key,
+				CodeMaxLength,
+				Params{"max": maxlength},
+				v.T.ValidateMaxChar(maxlength),
+			)
+			return nil
 		}
 	}
 	return property
@@ -86,7 +104,7 @@ func (v *Validator) ParseString(
 	property *string,
 ) {
 	if property == nil {
-		v.Check(false, key, v.T.ValidateRequired())
+		v.CheckCode(false, key, CodeRequired, nil, v.T.ValidateRequired())
 		return
 	}
 
@@ -95,14 +113,22 @@ func (v *Validator) ParseString(
 		" ",
 	)
 	if !startsWithLetter(*property) {
-		v.Check(false, key, v.T.ValidateStartWithLetter())
+		v.CheckCode(
+			false,
+			key,
+			CodeStartWithLetter,
+			nil,
+			v.T.ValidateStartWithLetter(),
+		)
 		return
 	}
 
 	if !allowedAlphanumericDashAndUnderscores(*property) {
-		v.Check(
+		v.CheckCode(
 			false,
 			key,
+			CodeAlphanumeric,
+			nil,
 			v.T.ValidateAlphanumericDashUnderscoreCharactersOnly(),
 		)
 		return
@@ -0,0 +1,86 @@
+package validator
+
+import "testing"
+
+func TestAssignStringEmpty(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantNil     bool
+		wantErr     bool
+	}{
+		{
+			name:        "empty form field is skipped",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "name=",
+		},
+		{
+			name:        "empty json string is checked",
+			contentType: "application/json",
+			body:        `{"name": ""}`,
+			wantNil:     true,
+			wantErr:     true,
+		},
+		{
+			name:        "json null clears",
+			contentType: "application/json",
+			body:        `{"name": null}`,
+			wantNil:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := newTestValidator(t, nil, tt.contentType, tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			name := "old"
+			got := v.AssignString("name", &name, 3, 10)
+			if (got == nil) != tt.wantNil {
+				t.Errorf("AssignString() = %v, want nil %v", got, tt.wantNil)
+			}
+			if got != nil && *got != "old" {
+				t.Errorf("AssignString() = %q, want old", *got)
+			}
+			if v.Valid() == tt.wantErr {
+				t.Errorf("Valid() = %v, want %v", v.Valid(), !tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAssignENUMEmpty(t *testing.T) {
+	type status string
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        status
+	}{
+		{
+			name:        "empty form field is skipped",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "status=",
+			want:        "active",
+		},
+		{
+			name:        "empty json string is assigned",
+			contentType: "application/json",
+			body:        `{"status": ""}`,
+			want:        "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := newTestValidator(t, nil, tt.contentType, tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := status("active")
+			if got := AssignENUM(v, "status", &s); *got != tt.want {
+				t.Errorf("AssignENUM() = %q, want %q", *got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package translations
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Arabic implements interfaces.Translation and
+// interfaces.SchemaTranslation in Arabic.
+type Arabic struct{}
+
+func (Arabic) ValidateRequired() string {
+	return "هذا الحقل مطلوب"
+}
+
+func (Arabic) ValidateRequiredArray() string {
+	return "يجب أن تكون قائمة غير فارغة"
+}
+
+func (Arabic) ValidateDate() string {
+	return "يجب أن يكون تاريخًا صحيحًا"
+}
+
+func (Arabic) ValidateBool() string {
+	return "يجب أن تكون القيمة صحيح أو خطأ"
+}
+
+func (Arabic) ValidateInt() string {
+	return "يجب أن يكون عددًا صحيحًا"
+}
+
+func (Arabic) ValidateRequiredFloat() string {
+	return "يجب أن يكون رقمًا"
+}
+
+func (Arabic) ValidateUUID() string {
+	return "يجب أن يكون معرفًا (uuid) صحيحًا"
+}
+
+func (Arabic) ValidateID() string {
+	return "يجب أن يكون معرفًا صحيحًا"
+}
+
+func (Arabic) ValidateExistsInDB() string {
+	return "غير موجود"
+}
+
+func (Arabic) ValidateNotExistsInDB() string {
+	return "موجود مسبقًا"
+}
+
+func (Arabic) ValidateMustBeInList(arg *[]string) string {
+	if arg == nil {
+		return "يجب أن تكون إحدى القيم المسموح بها"
+	}
+	return "يجب أن تكون إحدى القيم: " + strings.Join(*arg, "، ")
+}
+
+func (Arabic) ValidateNotEmptyRoles() string {
+	return "يجب أن يكون لديه دور واحد على الأقل"
+}
+
+func (Arabic) ValidateMustHaveRole(role string) string {
+	return "يجب أن يكون لديه الدور: " + role
+}
+
+func (Arabic) ValidateMustBeGteZero() string {
+	return "يجب أن يكون أكبر من أو يساوي 0"
+}
+
+func (Arabic) ValidateMustBeGtZero() string {
+	return "يجب أن يكون أكبر من 0"
+}
+
+func (Arabic) ValidateMustBeLteValue(value int) string {
+	return "يجب أن يكون أصغر من أو يساوي " + strconv.Itoa(value)
+}
+
+func (Arabic) ValidateMinChar(value int) string {
+	return "يجب ألا يقل عن " + arabicChars(value)
+}
+
+func (Arabic) ValidateMaxChar(value int) string {
+	return "يجب ألا يزيد عن " + arabicChars(value)
+}
+
+func (Arabic) ValidateMustBeGteFloatValue(value float64) string {
+	return "يجب أن يكون أكبر من أو يساوي " +
+		strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func (Arabic) ValidateEmail() string {
+	return "يجب أن يكون بريدًا إلكترونيًا صحيحًا"
+}
+
+func (Arabic) ValidateStartWithLetter() string {
+	return "يجب أن يبدأ بحرف"
+}
+
+func (Arabic) ValidateAlphanumericDashUnderscoreCharactersOnly() string {
+	return "يجب أن يحتوي على حروف وأرقام وشرطات وشرطات سفلية فقط"
+}
+
+func (Arabic) ValidatePasswordConfirmationNoMatch() string {
+	return "تأكيد كلمة المرور غير مطابق"
+}
+
+func (Arabic) ValidateCategoryInput() string {
+	return "التصنيف غير صحيح"
+}
+
+func (Arabic) ValidateCategoryParent() string {
+	return "التصنيف الأب غير صحيح"
+}
+
+func (Arabic) UnDestroyableCategory() string {
+	return "لا يمكن حذف هذا التصنيف"
+}
+
+func (Arabic) UnsupportedLocation(name string) string {
+	return "غير مدعوم في: " + name
+}
+
+func (Arabic) NotPermitted(scopes, allowed []string) string {
+	if len(allowed) == 0 {
+		return "غير مسموح"
+	}
+	return "غير مسموح، يتطلب أحد الصلاحيات: " + strings.Join(allowed, "، ")
+}
+
+func (Arabic) UserAlreadyVerified() string {
+	return "تم توثيق المستخدم مسبقًا"
+}
+
+func (Arabic) FileIsNotAnImage() string {
+	return "الملف ليس صورة"
+}
+
+func (Arabic) ModelName(name string) string {
+	return humanize(name)
+}
+
+func (a Arabic) ModelNotFound(name string) string {
+	return "لم يتم العثور على " + a.ModelName(name)
+}
+
+func (a Arabic) ModelDisabled(name string) string {
+	return a.ModelName(name) + " معطل"
+}
+
+func (Arabic) BadRequest() string {
+	return "طلب غير صالح"
+}
+
+func (Arabic) ConflictError() string {
+	return "يتعارض الطلب مع الحالة الحالية للمورد"
+}
+
+func (Arabic) DeletedAccount() string {
+	return "تم حذف هذا الحساب"
+}
+
+func (Arabic) DisabledAccount() string {
+	return "تم تعطيل هذا الحساب"
+}
+
+func (Arabic) InputValidation() string {
+	return "البيانات المدخلة غير صحيحة"
+}
+
+func (Arabic) InternalServerError() string {
+	return "خطأ داخلي في الخادم"
+}
+
+func (Arabic) InvalidCredentials() string {
+	return "بيانات الدخول غير صحيحة"
+}
+
+func (Arabic) JwtExpired() string {
+	return "انتهت الجلسة، يرجى تسجيل الدخول مجددًا"
+}
+
+func (Arabic) LoggedOut() string {
+	return "تم تسجيل الخروج بنجاح"
+}
+
+func (Arabic) MethodNotAllowed() string {
+	return "الطريقة غير مسموح بها"
+}
+
+func (Arabic) NotFound() string {
+	return "غير موجود"
+}
+
+func (Arabic) NotLoggedIn() string {
+	return "يجب تسجيل الدخول"
+}
+
+func (Arabic) OutOfScopeError() string {
+	return "ليس لديك صلاحية الوصول إلى هذا المورد"
+}
+
+func (Arabic) ProfileCleared() string {
+	return "تم مسح الملف الشخصي بنجاح"
+}
+
+func (Arabic) UnauthorizedAccess() string {
+	return "وصول غير مصرح به"
+}
+
+func (Arabic) OTPSentSuccessfully() string {
+	return "تم إرسال رمز التحقق بنجاح"
+}
+
+func (Arabic) WalletTransactionAlreadyConfirmed() string {
+	return "تم تأكيد معاملة المحفظة مسبقًا"
+}
+
+func (Arabic) ValidateSchemaKeyword(
+	keyword string,
+	params map[string]any,
+) string {
+	switch keyword {
+	case "minimum":
+		return fmt.Sprintf("يجب ألا يقل عن %v", params["min"])
+	case "exclusiveMinimum":
+		return fmt.Sprintf("يجب أن يكون أكبر من %v", params["min"])
+	case "maximum":
+		return fmt.Sprintf("يجب ألا يزيد عن %v", params["max"])
+	case "exclusiveMaximum":
+		return fmt.Sprintf("يجب أن يكون أصغر من %v", params["max"])
+	case "minItems":
+		if n, ok := params["min"].(int); ok {
+			return "يجب ألا يقل عن " + arabicItems(n)
+		}
+	case "maxItems":
+		if n, ok := params["max"].(int); ok {
+			return "يجب ألا يزيد عن " + arabicItems(n)
+		}
+	case "format":
+		return fmt.Sprintf("يجب أن يكون بصيغة %v صحيحة", params["format"])
+	case "type":
+		return fmt.Sprintf("يجب أن يكون من النوع %v", params["type"])
+	case "pattern":
+		return "الصيغة غير صحيحة"
+	case "additionalProperties":
+		return "يحتوي على خصائص غير معروفة"
+	case "uniqueItems":
+		return "يجب ألا يحتوي على عناصر مكررة"
+	}
+	return "قيمة غير صحيحة"
+}
+
+// Message returns the messages that have no Translation method by their
+// code, see interfaces.MessageTranslation.
+func (Arabic) Message(code string, params map[string]any) (string, bool) {
+	switch code {
+	case "timeout":
+		return "انتهت مهلة الطلب، يرجى المحاولة مجددًا", true
+	case "canceled":
+		return "تم إلغاء الطلب", true
+	case "body_too_large":
+		return "حجم محتوى الطلب كبير جدًا", true
+	case "unsupported_media_type":
+		return "نوع محتوى الطلب غير مدعوم", true
+	case "unknown_key":
+		return "حقل غير معروف", true
+	case "min_files":
+		n, _ := intParam(params, "min")
+		return "يجب ألا يقل عدد الملفات عن " + arabicFiles(n), true
+	case "max_files":
+		n, _ := intParam(params, "max")
+		return "يجب ألا يزيد عدد الملفات عن " + arabicFiles(n), true
+	case "file_size":
+		n, _ := intParam(params, "max")
+		return "يجب ألا يتجاوز حجم الملف " + byteSize(n, arabicUnits), true
+	case "duplicate":
+		n, _ := intParam(params, "index")
+		return "يجب ألا يكرر العنصر " + strconv.Itoa(n), true
+	}
+	return "", false
+}
+
+// arabicPlural picks the form of a counted noun following the CLDR plural
+// categories of Arabic: zero, one, two, few (3-10), many (11-99) and other.
+func arabicPlural(n int, one, two, few, many, other string) string {
+	switch mod := n % 100; {
+	case n == 1:
+		return one
+	case n == 2:
+		return two
+	case mod >= 3 && mod <= 10:
+		return fmt.Sprintf("%d %s", n, few)
+	case mod >= 11 && mod <= 99:
+		return fmt.Sprintf("%d %s", n, many)
+	default:
+		return fmt.Sprintf("%d %s", n, other)
+	}
+}
+
+func arabicChars(n int) string {
+	return arabicPlural(n, "حرف واحد", "حرفين", "أحرف", "حرفًا", "حرف")
+}
+
+var arabicUnits = [...]string{"بايت", "كيلوبايت", "ميغابايت", "غيغابايت"}
+
+func arabicFiles(n int) string {
+	return arabicPlural(n, "ملف واحد", "ملفين", "ملفات", "ملفًا", "ملف")
+}
+
+func arabicItems(n int) string {
+	return arabicPlural(n, "عنصر واحد", "عنصرين", "عناصر", "عنصرًا", "عنصر")
+}
@@ -0,0 +1,549 @@
+package translations
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/m-row/validator/interfaces"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	_ interfaces.Translation        = (*Catalog)(nil)
+	_ interfaces.SchemaTranslation  = (*Catalog)(nil)
+	_ interfaces.MessageTranslation = (*Catalog)(nil)
+)
+
+// Plural categories, see PluralCategory.
+const (
+	Zero  = "zero"
+	One   = "one"
+	Two   = "two"
+	Few   = "few"
+	Many  = "many"
+	Other = "other"
+)
+
+// Message holds the text of a message by plural category, a message without
+// plural forms only has Other.
+type Message map[string]string
+
+// Catalog is a Translation backed by messages loaded from a file, messages
+// are looked up by code and may hold {name} placeholders filled from their
+// params:
+//
+//	# ar.yaml
+//	required: هذا الحقل مطلوب
+//	min_length:
+//	  one: يجب ألا يقل عن حرف واحد
+//	  two: يجب ألا يقل عن حرفين
+//	  few: يجب ألا يقل عن {count} أحرف
+//	  many: يجب ألا يقل عن {count} حرفًا
+//	  other: يجب ألا يقل عن {count} حرف
+//	model.products: المنتجات
+//
+// The plural form is picked from the count param using the rules of Locale.
+// Codes missing from Messages are answered by Fallback.
+type Catalog struct {
+	Locale   string
+	Messages map[string]Message
+	Fallback interfaces.Translation
+}
+
+// LoadCatalog reads a .json, .yaml or .yml catalog from fsys, which can be an
+// embed.FS. The locale is the base name of the file: messages/ar.yaml is ar.
+// The fallback is the translation of that locale, see ForLocale.
+func LoadCatalog(fsys fs.FS, name string) (*Catalog, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	raw := map[string]any{}
+	ext := path.Ext(name)
+	switch strings.ToLower(ext) {
+	case ".json":
+		err = json.Unmarshal(data, &raw)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("unsupported catalog format: %s", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	locale := strings.TrimSuffix(path.Base(name), ext)
+	c := &Catalog{
+		Locale:   locale,
+		Messages: make(map[string]Message, len(raw)),
+		Fallback: ForLocale(locale),
+	}
+	for code, val := range raw {
+		switch val := val.(type) {
+		case string:
+			c.Messages[code] = Message{Other: val}
+		case map[string]any:
+			msg := make(Message, len(val))
+			for category, text := range val {
+				msg[category] = fmt.Sprint(text)
+			}
+			c.Messages[code] = msg
+		default:
+			return nil, fmt.Errorf("%s: invalid message: %s", name, code)
+		}
+	}
+	return c, nil
+}
+
+// LoadCatalogs loads every catalog of fsys matching pattern, see fs.Glob:
+//
+//	//go:embed messages
+//	var messages embed.FS
+//
+//	catalogs, err := translations.LoadCatalogs(messages, "messages/*.yaml")
+func LoadCatalogs(fsys fs.FS, pattern string) ([]*Catalog, error) {
+	names, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	catalogs := make([]*Catalog, 0, len(names))
+	for _, name := range names {
+		c, err := LoadCatalog(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		catalogs = append(catalogs, c)
+	}
+	return catalogs, nil
+}
+
+var placeholder = regexp.MustCompile(`\{([A-Za-z0-9_]+)\}`)
+
+// Message returns the message of code with its placeholders replaced by
+// params, or the message of Fallback when the catalog has no such message.
+func (c *Catalog) Message(
+	code string,
+	params map[string]any,
+) (string, bool) {
+	msg, found := c.Messages[code]
+	if !found {
+		if mt, ok := c.Fallback.(interfaces.MessageTranslation); ok {
+			return mt.Message(code, params)
+		}
+		return "", false
+	}
+	text, found := msg[Other]
+	if n, ok := intParam(params, "count"); ok {
+		if plural, ok := msg[PluralCategory(c.Locale, n)]; ok {
+			text, found = plural, true
+		}
+	}
+	if !found {
+		return "", false
+	}
+	return placeholder.ReplaceAllStringFunc(text, func(m string) string {
+		val, ok := params[m[1:len(m)-1]]
+		if !ok {
+			return m
+		}
+		if list, ok := val.([]string); ok {
+			return strings.Join(list, ", ")
+		}
+		return fmt.Sprint(val)
+	}), true
+}
+
+// PluralCategory returns the CLDR plural category of n in locale, Arabic has
+// all six categories while other locales only use one and other.
+func PluralCategory(locale string, n int) string {
+	lang, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	lang, _, _ = strings.Cut(lang, "_")
+	if lang != "ar" {
+		if n == 1 {
+			return One
+		}
+		return Other
+	}
+	switch mod := n % 100; {
+	case n == 0:
+		return Zero
+	case n == 1:
+		return One
+	case n == 2:
+		return Two
+	case mod >= 3 && mod <= 10:
+		return Few
+	case mod >= 11 && mod <= 99:
+		return Many
+	default:
+		return Other
+	}
+}
+
+// intParam returns the number held by params[name].
+func intParam(params map[string]any, name string) (int, bool) {
+	switch n := params[name].(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	}
+	return 0, false
+}
+
+// text returns the message of code, or fallback when there's none.
+func (c *Catalog) text(
+	code string,
+	params map[string]any,
+	fallback func(interfaces.Translation) string,
+) string {
+	if msg, found := c.Message(code, params); found {
+		return msg
+	}
+	if c.Fallback == nil {
+		return fallback(English{})
+	}
+	return fallback(c.Fallback)
+}
+
+func (c *Catalog) ValidateRequired() string {
+	return c.text("required", nil, interfaces.Translation.ValidateRequired)
+}
+
+func (c *Catalog) ValidateRequiredArray() string {
+	return c.text(
+		"required_array",
+		nil,
+		interfaces.Translation.ValidateRequiredArray,
+	)
+}
+
+func (c *Catalog) ValidateDate() string {
+	return c.text("date", nil, interfaces.Translation.ValidateDate)
+}
+
+func (c *Catalog) ValidateBool() string {
+	return c.text("bool", nil, interfaces.Translation.ValidateBool)
+}
+
+func (c *Catalog) ValidateInt() string {
+	return c.text("int", nil, interfaces.Translation.ValidateInt)
+}
+
+func (c *Catalog) ValidateRequiredFloat() string {
+	return c.text("float", nil, interfaces.Translation.ValidateRequiredFloat)
+}
+
+func (c *Catalog) ValidateUUID() string {
+	return c.text("uuid", nil, interfaces.Translation.ValidateUUID)
+}
+
+func (c *Catalog) ValidateID() string {
+	return c.text("id", nil, interfaces.Translation.ValidateID)
+}
+
+func (c *Catalog) ValidateExistsInDB() string {
+	return c.text("not_exists", nil, interfaces.Translation.ValidateExistsInDB)
+}
+
+func (c *Catalog) ValidateNotExistsInDB() string {
+	return c.text(
+		"already_exists",
+		nil,
+		interfaces.Translation.ValidateNotExistsInDB,
+	)
+}
+
+func (c *Catalog) ValidateMustBeInList(arg *[]string) string {
+	params := map[string]any{}
+	if arg != nil {
+		params["allowed"] = *arg
+	}
+	return c.text("enum", params, func(t interfaces.Translation) string {
+		return t.ValidateMustBeInList(arg)
+	})
+}
+
+func (c *Catalog) ValidateNotEmptyRoles() string {
+	return c.text(
+		"not_empty_roles",
+		nil,
+		interfaces.Translation.ValidateNotEmptyRoles,
+	)
+}
+
+func (c *Catalog) ValidateMustHaveRole(role string) string {
+	params := map[string]any{"role": role}
+	return c.text(
+		"must_have_role",
+		params,
+		func(t interfaces.Translation) string {
+			return t.ValidateMustHaveRole(role)
+		},
+	)
+}
+
+func (c *Catalog) ValidateMustBeGteZero() string {
+	return c.text("gte_zero", nil, interfaces.Translation.ValidateMustBeGteZero)
+}
+
+func (c *Catalog) ValidateMustBeGtZero() string {
+	return c.text("gt_zero", nil, interfaces.Translation.ValidateMustBeGtZero)
+}
+
+func (c *Catalog) ValidateMustBeLteValue(value int) string {
+	params := map[string]any{"max": value, "count": value}
+	return c.text("max", params, func(t interfaces.Translation) string {
+		return t.ValidateMustBeLteValue(value)
+	})
+}
+
+func (c *Catalog) ValidateMinChar(value int) string {
+	params := map[string]any{"min": value, "count": value}
+	return c.text("min_length", params, func(t interfaces.Translation) string {
+		return t.ValidateMinChar(value)
+	})
+}
+
+func (c *Catalog) ValidateMaxChar(value int) string {
+	params := map[string]any{"max": value, "count": value}
+	return c.text("max_length", params, func(t interfaces.Translation) string {
+		return t.ValidateMaxChar(value)
+	})
+}
+
+func (c *Catalog) ValidateMustBeGteFloatValue(value float64) string {
+	params := map[string]any{"min": value}
+	return c.text("min", params, func(t interfaces.Translation) string {
+		return t.ValidateMustBeGteFloatValue(value)
+	})
+}
+
+func (c *Catalog) ValidateEmail() string {
+	return c.text("email", nil, interfaces.Translation.ValidateEmail)
+}
+
+func (c *Catalog) ValidateStartWithLetter() string {
+	return c.text(
+		"start_with_letter",
+		nil,
+		interfaces.Translation.ValidateStartWithLetter,
+	)
+}
+
+func (c *Catalog) ValidateAlphanumericDashUnderscoreCharactersOnly() string {
+	return c.text(
+		"alphanumeric_dash_underscore",
+		nil,
+		interfaces.Translation.ValidateAlphanumericDashUnderscoreCharactersOnly,
+	)
+}
+
+func (c *Catalog) ValidatePasswordConfirmationNoMatch() string {
+	return c.text(
+		"password_confirmation",
+		nil,
+		interfaces.Translation.ValidatePasswordConfirmationNoMatch,
+	)
+}
+
+func (c *Catalog) ValidateCategoryInput() string {
+	return c.text("category", nil, interfaces.Translation.ValidateCategoryInput)
+}
+
+func (c *Catalog) ValidateCategoryParent() string {
+	return c.text(
+		"category_parent",
+		nil,
+		interfaces.Translation.ValidateCategoryParent,
+	)
+}
+
+func (c *Catalog) UnDestroyableCategory() string {
+	return c.text(
+		"undestroyable_category",
+		nil,
+		interfaces.Translation.UnDestroyableCategory,
+	)
+}
+
+func (c *Catalog) UnsupportedLocation(name string) string {
+	params := map[string]any{"name": name}
+	return c.text(
+		"unsupported_source",
+		params,
+		func(t interfaces.Translation) string {
+			return t.UnsupportedLocation(name)
+		},
+	)
+}
+
+func (c *Catalog) NotPermitted(scopes, allowed []string) string {
+	params := map[string]any{"scopes": scopes, "allowed": allowed}
+	return c.text(
+		"not_permitted",
+		params,
+		func(t interfaces.Translation) string {
+			return t.NotPermitted(scopes, allowed)
+		},
+	)
+}
+
+func (c *Catalog) UserAlreadyVerified() string {
+	return c.text(
+		"user_already_verified",
+		nil,
+		interfaces.Translation.UserAlreadyVerified,
+	)
+}
+
+func (c *Catalog) FileIsNotAnImage() string {
+	return c.text("not_image", nil, interfaces.Translation.FileIsNotAnImage)
+}
+
+// ModelName returns the model.<name> message, such as model.products.
+func (c *Catalog) ModelName(name string) string {
+	return c.text("model."+name, nil, func(t interfaces.Translation) string {
+		return t.ModelName(name)
+	})
+}
+
+func (c *Catalog) ModelNotFound(name string) string {
+	params := map[string]any{"model": c.ModelName(name)}
+	return c.text(
+		"model_not_found",
+		params,
+		func(t interfaces.Translation) string {
+			return t.ModelNotFound(name)
+		},
+	)
+}
+
+func (c *Catalog) ModelDisabled(name string) string {
+	params := map[string]any{"model": c.ModelName(name)}
+	return c.text(
+		"model_disabled",
+		params,
+		func(t interfaces.Translation) string {
+			return t.ModelDisabled(name)
+		},
+	)
+}
+
+func (c *Catalog) BadRequest() string {
+	return c.text("bad_request", nil, interfaces.Translation.BadRequest)
+}
+
+func (c *Catalog) ConflictError() string {
+	return c.text("conflict", nil, interfaces.Translation.ConflictError)
+}
+
+func (c *Catalog) DeletedAccount() string {
+	return c.text("deleted_account", nil, interfaces.Translation.DeletedAccount)
+}
+
+func (c *Catalog) DisabledAccount() string {
+	return c.text(
+		"disabled_account",
+		nil,
+		interfaces.Translation.DisabledAccount,
+	)
+}
+
+func (c *Catalog) InputValidation() string {
+	return c.text(
+		"input_validation",
+		nil,
+		interfaces.Translation.InputValidation,
+	)
+}
+
+func (c *Catalog) InternalServerError() string {
+	return c.text(
+		"internal_server_error",
+		nil,
+		interfaces.Translation.InternalServerError,
+	)
+}
+
+func (c *Catalog) InvalidCredentials() string {
+	return c.text(
+		"invalid_credentials",
+		nil,
+		interfaces.Translation.InvalidCredentials,
+	)
+}
+
+func (c *Catalog) JwtExpired() string {
+	return c.text("jwt_expired", nil, interfaces.Translation.JwtExpired)
+}
+
+func (c *Catalog) LoggedOut() string {
+	return c.text("logged_out", nil, interfaces.Translation.LoggedOut)
+}
+
+func (c *Catalog) MethodNotAllowed() string {
+	return c.text(
+		"method_not_allowed",
+		nil,
+		interfaces.Translation.MethodNotAllowed,
+	)
+}
+
+func (c *Catalog) NotFound() string {
+	return c.text("not_found", nil, interfaces.Translation.NotFound)
+}
+
+func (c *Catalog) NotLoggedIn() string {
+	return c.text("not_logged_in", nil, interfaces.Translation.NotLoggedIn)
+}
+
+func (c *Catalog) OutOfScopeError() string {
+	return c.text("out_of_scope", nil, interfaces.Translation.OutOfScopeError)
+}
+
+func (c *Catalog) ProfileCleared() string {
+	return c.text("profile_cleared", nil, interfaces.Translation.ProfileCleared)
+}
+
+func (c *Catalog) UnauthorizedAccess() string {
+	return c.text(
+		"unauthorized_access",
+		nil,
+		interfaces.Translation.UnauthorizedAccess,
+	)
+}
+
+func (c *Catalog) OTPSentSuccessfully() string {
+	return c.text("otp_sent", nil, interfaces.Translation.OTPSentSuccessfully)
+}
+
+func (c *Catalog) WalletTransactionAlreadyConfirmed() string {
+	return c.text(
+		"wallet_transaction_already_confirmed",
+		nil,
+		interfaces.Translation.WalletTransactionAlreadyConfirmed,
+	)
+}
+
+// ValidateSchemaKeyword returns the schema.<keyword> message, such as
+// schema.pattern.
+func (c *Catalog) ValidateSchemaKeyword(
+	keyword string,
+	params map[string]any,
+) string {
+	if msg, found := c.Message("schema."+keyword, params); found {
+		return msg
+	}
+	if st, ok := c.Fallback.(interfaces.SchemaTranslation); ok {
+		return st.ValidateSchemaKeyword(keyword, params)
+	}
+	return English{}.ValidateSchemaKeyword(keyword, params)
+}
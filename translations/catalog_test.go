@@ -0,0 +1,55 @@
+package translations
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestCatalogMessages(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.yaml": &fstest.MapFile{Data: []byte(
+			"enum: must be one of {allowed}\n" +
+				"min_length:\n" +
+				"  one: at least 1 character\n" +
+				"  other: at least {count} characters\n",
+		)},
+	}
+	c, err := LoadCatalog(fsys, "en.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{
+			name: "enum",
+			got:  c.ValidateMustBeInList(&[]string{"a", "b"}),
+			want: "must be one of a, b",
+		},
+		{
+			name: "plural one",
+			got:  c.ValidateMinChar(1),
+			want: "at least 1 character",
+		},
+		{
+			name: "plural other",
+			got:  c.ValidateMinChar(3),
+			want: "at least 3 characters",
+		},
+		{
+			name: "fallback",
+			got:  c.ValidateRequired(),
+			want: English{}.ValidateRequired(),
+		},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+	if msg, _ := c.Message("timeout", nil); msg == "" {
+		t.Error("codes missing from the catalog aren't read from Fallback")
+	}
+}
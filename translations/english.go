@@ -0,0 +1,296 @@
+package translations
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// English implements interfaces.Translation and
+// interfaces.SchemaTranslation in English.
+type English struct{}
+
+func (English) ValidateRequired() string {
+	return "required"
+}
+
+func (English) ValidateRequiredArray() string {
+	return "must be a non empty list"
+}
+
+func (English) ValidateDate() string {
+	return "must be a valid date"
+}
+
+func (English) ValidateBool() string {
+	return "must be true or false"
+}
+
+func (English) ValidateInt() string {
+	return "must be an integer"
+}
+
+func (English) ValidateRequiredFloat() string {
+	return "must be a number"
+}
+
+func (English) ValidateUUID() string {
+	return "must be a valid uuid"
+}
+
+func (English) ValidateID() string {
+	return "must be a valid id"
+}
+
+func (English) ValidateExistsInDB() string {
+	return "does not exist"
+}
+
+func (English) ValidateNotExistsInDB() string {
+	return "already exists"
+}
+
+func (English) ValidateMustBeInList(arg *[]string) string {
+	if arg == nil {
+		return "must be one of the allowed values"
+	}
+	return "must be one of: " + strings.Join(*arg, ", ")
+}
+
+func (English) ValidateNotEmptyRoles() string {
+	return "must have at least one role"
+}
+
+func (English) ValidateMustHaveRole(role string) string {
+	return "must have the role: " + role
+}
+
+func (English) ValidateMustBeGteZero() string {
+	return "must be greater than or equal to 0"
+}
+
+func (English) ValidateMustBeGtZero() string {
+	return "must be greater than 0"
+}
+
+func (English) ValidateMustBeLteValue(value int) string {
+	return "must be less than or equal to " + strconv.Itoa(value)
+}
+
+func (English) ValidateMinChar(value int) string {
+	return fmt.Sprintf("must be at least %s", englishChars(value))
+}
+
+func (English) ValidateMaxChar(value int) string {
+	return fmt.Sprintf("must be at most %s", englishChars(value))
+}
+
+func (English) ValidateMustBeGteFloatValue(value float64) string {
+	return "must be greater than or equal to " +
+		strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func (English) ValidateEmail() string {
+	return "must be a valid email address"
+}
+
+func (English) ValidateStartWithLetter() string {
+	return "must start with a letter"
+}
+
+func (English) ValidateAlphanumericDashUnderscoreCharactersOnly() string {
+	return "must contain only letters, numbers, dashes and underscores"
+}
+
+func (English) ValidatePasswordConfirmationNoMatch() string {
+	return "password confirmation does not match"
+}
+
+func (English) ValidateCategoryInput() string {
+	return "invalid category"
+}
+
+func (English) ValidateCategoryParent() string {
+	return "invalid parent category"
+}
+
+func (English) UnDestroyableCategory() string {
+	return "this category can't be deleted"
+}
+
+func (English) UnsupportedLocation(name string) string {
+	return "not supported in: " + name
+}
+
+func (English) NotPermitted(scopes, allowed []string) string {
+	if len(allowed) == 0 {
+		return "not permitted"
+	}
+	return "not permitted, requires one of: " + strings.Join(allowed, ", ")
+}
+
+func (English) UserAlreadyVerified() string {
+	return "user is already verified"
+}
+
+func (English) FileIsNotAnImage() string {
+	return "file is not an image"
+}
+
+func (English) ModelName(name string) string {
+	return humanize(name)
+}
+
+func (e English) ModelNotFound(name string) string {
+	return e.ModelName(name) + " not found"
+}
+
+func (e English) ModelDisabled(name string) string {
+	return e.ModelName(name) + " is disabled"
+}
+
+func (English) BadRequest() string {
+	return "bad request"
+}
+
+func (English) ConflictError() string {
+	return "conflict with the current state of the resource"
+}
+
+func (English) DeletedAccount() string {
+	return "this account has been deleted"
+}
+
+func (English) DisabledAccount() string {
+	return "this account has been disabled"
+}
+
+func (English) InputValidation() string {
+	return "invalid input"
+}
+
+func (English) InternalServerError() string {
+	return "internal server error"
+}
+
+func (English) InvalidCredentials() string {
+	return "invalid credentials"
+}
+
+func (English) JwtExpired() string {
+	return "session expired, please log in again"
+}
+
+func (English) LoggedOut() string {
+	return "logged out successfully"
+}
+
+func (English) MethodNotAllowed() string {
+	return "method not allowed"
+}
+
+func (English) NotFound() string {
+	return "not found"
+}
+
+func (English) NotLoggedIn() string {
+	return "you must be logged in"
+}
+
+func (English) OutOfScopeError() string {
+	return "you don't have access to this resource"
+}
+
+func (English) ProfileCleared() string {
+	return "profile cleared successfully"
+}
+
+func (English) UnauthorizedAccess() string {
+	return "unauthorized access"
+}
+
+func (English) OTPSentSuccessfully() string {
+	return "verification code sent successfully"
+}
+
+func (English) WalletTransactionAlreadyConfirmed() string {
+	return "wallet transaction is already confirmed"
+}
+
+func (English) ValidateSchemaKeyword(
+	keyword string,
+	params map[string]any,
+) string {
+	switch keyword {
+	case "minimum":
+		return fmt.Sprintf("must be at least %v", params["min"])
+	case "exclusiveMinimum":
+		return fmt.Sprintf("must be greater than %v", params["min"])
+	case "maximum":
+		return fmt.Sprintf("must be at most %v", params["max"])
+	case "exclusiveMaximum":
+		return fmt.Sprintf("must be less than %v", params["max"])
+	case "minItems":
+		return fmt.Sprintf("must have at least %v items", params["min"])
+	case "maxItems":
+		return fmt.Sprintf("must have at most %v items", params["max"])
+	case "format":
+		return fmt.Sprintf("must be a valid %v", params["format"])
+	case "type":
+		return fmt.Sprintf("must be of type %v", params["type"])
+	case "pattern":
+		return "has an invalid format"
+	case "additionalProperties":
+		return "has unknown properties"
+	case "uniqueItems":
+		return "must not contain duplicates"
+	default:
+		return "invalid value"
+	}
+}
+
+// Message returns the messages that have no Translation method by their
+// code, see interfaces.MessageTranslation.
+func (English) Message(code string, params map[string]any) (string, bool) {
+	switch code {
+	case "timeout":
+		return "the request timed out, please try again", true
+	case "canceled":
+		return "the request was canceled", true
+	case "body_too_large":
+		return "the request body is too large", true
+	case "unsupported_media_type":
+		return "the request body has an unsupported media type", true
+	case "unknown_key":
+		return "unknown field", true
+	case "min_files":
+		n, _ := intParam(params, "min")
+		return "must have at least " + englishFiles(n), true
+	case "max_files":
+		n, _ := intParam(params, "max")
+		return "must have at most " + englishFiles(n), true
+	case "file_size":
+		n, _ := intParam(params, "max")
+		return "must not be larger than " + byteSize(n, englishUnits), true
+	case "duplicate":
+		n, _ := intParam(params, "index")
+		return "must not repeat element " + strconv.Itoa(n), true
+	}
+	return "", false
+}
+
+var englishUnits = [...]string{"bytes", "KB", "MB", "GB"}
+
+func englishFiles(n int) string {
+	if n == 1 {
+		return "1 file"
+	}
+	return strconv.Itoa(n) + " files"
+}
+
+func englishChars(n int) string {
+	if n == 1 {
+		return "1 character"
+	}
+	return strconv.Itoa(n) + " characters"
+}
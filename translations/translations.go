@@ -0,0 +1,56 @@
+// Package translations provides ready made English and Arabic implementations
+// of interfaces.Translation. Both are meant to be embedded so that an app only
+// overrides the messages it needs:
+//
+//	type Messages struct {
+//		translations.English
+//	}
+//
+//	func (Messages) ValidateRequired() string {
+//		return "can't be blank"
+//	}
+package translations
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/m-row/validator/interfaces"
+)
+
+var (
+	_ interfaces.Translation        = English{}
+	_ interfaces.SchemaTranslation  = English{}
+	_ interfaces.MessageTranslation = English{}
+	_ interfaces.Translation        = Arabic{}
+	_ interfaces.SchemaTranslation  = Arabic{}
+	_ interfaces.MessageTranslation = Arabic{}
+)
+
+// ForLocale returns the translation of a BCP 47 language tag, Arabic for any
+// tag of the ar language (ar, ar-LY, ar-EG...) and English otherwise.
+func ForLocale(tag string) interfaces.Translation {
+	lang, _, _ := strings.Cut(strings.ToLower(tag), "-")
+	lang, _, _ = strings.Cut(lang, "_")
+	if lang == "ar" {
+		return Arabic{}
+	}
+	return English{}
+}
+
+// byteSize formats n bytes in the largest of units (bytes, KB, MB, GB) that
+// divides it exactly: 2097152 becomes 2 MB.
+func byteSize(n int, units [4]string) string {
+	unit := 0
+	for unit < len(units)-1 && n >= 1024 && n%1024 == 0 {
+		n /= 1024
+		unit++
+	}
+	return strconv.Itoa(n) + " " + units[unit]
+}
+
+// humanize converts a table or model name to words: product_variants becomes
+// product variants.
+func humanize(name string) string {
+	return strings.ReplaceAll(name, "_", " ")
+}
@@ -0,0 +1,99 @@
+package translations
+
+import "testing"
+
+func TestValidateSchemaKeyword(t *testing.T) {
+	tests := []struct {
+		keyword string
+		params  map[string]any
+		english string
+		arabic  string
+	}{
+		{
+			keyword: "minimum",
+			params:  map[string]any{"min": 5},
+			english: "must be at least 5",
+			arabic:  "يجب ألا يقل عن 5",
+		},
+		{
+			keyword: "exclusiveMinimum",
+			params:  map[string]any{"min": 5},
+			english: "must be greater than 5",
+			arabic:  "يجب أن يكون أكبر من 5",
+		},
+		{
+			keyword: "maximum",
+			params:  map[string]any{"max": 9},
+			english: "must be at most 9",
+			arabic:  "يجب ألا يزيد عن 9",
+		},
+		{
+			keyword: "exclusiveMaximum",
+			params:  map[string]any{"max": 9},
+			english: "must be less than 9",
+			arabic:  "يجب أن يكون أصغر من 9",
+		},
+		{
+			keyword: "minItems",
+			params:  map[string]any{"min": 2},
+			english: "must have at least 2 items",
+			arabic:  "يجب ألا يقل عن عنصرين",
+		},
+		{
+			keyword: "pattern",
+			params:  map[string]any{"pattern": "^[a-z]+$"},
+			english: "has an invalid format",
+			arabic:  "الصيغة غير صحيحة",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.keyword, func(t *testing.T) {
+			got := English{}.ValidateSchemaKeyword(tt.keyword, tt.params)
+			if got != tt.english {
+				t.Errorf("English: got %q, want %q", got, tt.english)
+			}
+			got = Arabic{}.ValidateSchemaKeyword(tt.keyword, tt.params)
+			if got != tt.arabic {
+				t.Errorf("Arabic: got %q, want %q", got, tt.arabic)
+			}
+		})
+	}
+}
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		code    string
+		params  map[string]any
+		english string
+		arabic  string
+	}{
+		{
+			code:    "duplicate",
+			params:  map[string]any{"index": 2},
+			english: "must not repeat element 2",
+			arabic:  "يجب ألا يكرر العنصر 2",
+		},
+		{
+			code:    "min_files",
+			params:  map[string]any{"min": 2},
+			english: "must have at least 2 files",
+		},
+		{
+			code:    "unknown_key",
+			english: "unknown field",
+			arabic:  "حقل غير معروف",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			got, found := English{}.Message(tt.code, tt.params)
+			if !found || got != tt.english {
+				t.Errorf("English: got %q, want %q", got, tt.english)
+			}
+			got, found = Arabic{}.Message(tt.code, tt.params)
+			if !found || (tt.arabic != "" && got != tt.arabic) {
+				t.Errorf("Arabic: got %q, want %q", got, tt.arabic)
+			}
+		})
+	}
+}
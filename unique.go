@@ -0,0 +1,141 @@
+package validator
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// UniqueConfig scopes a Unique check:
+//
+//	v.Unique("email", "users", m.Email, validator.UniqueConfig{
+//		Lower:    true,
+//		ExceptID: m.ID,
+//		Where:    []squirrel.Sqlizer{squirrel.Expr("deleted_at IS NULL")},
+//	})
+type UniqueConfig struct {
+	// Column compared by Unique, defaults to the key being checked.
+	Column string
+	// Lower compares lower(column) = lower(value) for case insensitive
+	// values such as emails.
+	Lower bool
+	// ExceptID excludes the row being updated, nil when creating.
+	// IDColumn defaults to id.
+	ExceptID any
+	IDColumn string
+	// Where adds predicates to the check, such as a tenant or a soft
+	// delete column.
+	Where []squirrel.Sqlizer
+}
+
+// Unique checks that no other row of table holds value, reporting the
+// ValidateNotExistsInDB message at key when one does. A nil value isn't
+// checked.
+func (v *Validator) Unique(
+	key, table string,
+	value any,
+	c UniqueConfig,
+) {
+	column := c.Column
+	if column == "" {
+		column = key
+	}
+	v.UniqueTogether(key, table, map[string]any{column: value}, c)
+}
+
+// UniqueTogether checks that no other row of table holds all of values,
+// keyed by column, together, reporting the failure at key:
+//
+//	v.UniqueTogether("slug", "products", map[string]any{
+//		"store_id": m.StoreID,
+//		"slug":     m.Slug,
+//	}, validator.UniqueConfig{ExceptID: m.ID})
+//
+// The check is skipped when values is empty or any of them is nil.
+func (v *Validator) UniqueTogether(
+	key, table string,
+	values map[string]any,
+	c UniqueConfig,
+) {
+	if len(values) == 0 {
+		return
+	}
+	snapshots := make(map[string]any, len(values))
+	for column, value := range values {
+		if isNil(value) {
+			return
+		}
+		snapshots[column] = snapshot(value)
+	}
+	c.ExceptID = snapshot(c.ExceptID)
+	where, err := v.uniqueWhere(snapshots, c)
+	if err != nil {
+		v.queryFailed(key, err)
+		return
+	}
+	v.run(func(v *Validator) {
+		// a value taken or freed by another request is seen at once
+		exists, ok := v.existsWhere(key, table, false, where...)
+		if !ok {
+			return
+		}
+		v.CheckCode(
+			!exists,
+			key,
+			CodeAlreadyExists,
+			nil,
+			v.T.ValidateNotExistsInDB(),
+		)
+	})
+}
+
+// uniqueWhere returns the predicates of UniqueTogether:
+//
+//	lower("email") = lower($1) AND "id" <> $2 AND deleted_at IS NULL
+func (v *Validator) uniqueWhere(
+	values map[string]any,
+	c UniqueConfig,
+) ([]squirrel.Sqlizer, error) {
+	columns := make([]string, 0, len(values))
+	for column := range values {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	where := make([]squirrel.Sqlizer, 0, len(columns)+len(c.Where)+1)
+	for _, column := range columns {
+		quoted, err := v.quote(column, 1)
+		if err != nil {
+			return nil, err
+		}
+		if c.Lower {
+			where = append(
+				where,
+				squirrel.Expr("lower("+quoted+") = lower(?)", values[column]),
+			)
+		} else {
+			where = append(where, squirrel.Expr(quoted+" = ?", values[column]))
+		}
+	}
+	if !isNil(c.ExceptID) {
+		idColumn := c.IDColumn
+		if idColumn == "" {
+			idColumn = "id"
+		}
+		quoted, err := v.quote(idColumn, 1)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, squirrel.Expr(quoted+" <> ?", c.ExceptID))
+	}
+	return append(where, c.Where...), nil
+}
+
+// isNil reports whether value is nil or a nil pointer.
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+	rv := reflect.ValueOf(value)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
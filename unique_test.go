@@ -0,0 +1,156 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+)
+
+func TestUnique(t *testing.T) {
+	id := 7
+	var noID *int
+	tests := []struct {
+		name   string
+		check  func(v *Validator)
+		exists bool
+		query  string
+		args   []any
+		failed bool
+	}{
+		{
+			name: "column",
+			check: func(v *Validator) {
+				v.Unique("email", "users", "a@b.c", UniqueConfig{})
+			},
+			exists: true,
+			query: `SELECT EXISTS(SELECT 1 FROM "users" ` +
+				`WHERE "email" = $1)`,
+			args:   []any{"a@b.c"},
+			failed: true,
+		},
+		{
+			name: "lower",
+			check: func(v *Validator) {
+				v.Unique("email", "users", "A@b.c", UniqueConfig{
+					Column: "mail",
+					Lower:  true,
+				})
+			},
+			query: `SELECT EXISTS(SELECT 1 FROM "users" ` +
+				`WHERE lower("mail") = lower($1))`,
+			args: []any{"A@b.c"},
+		},
+		{
+			name: "except id",
+			check: func(v *Validator) {
+				v.Unique("email", "users", "a@b.c", UniqueConfig{
+					ExceptID: &id,
+				})
+			},
+			query: `SELECT EXISTS(SELECT 1 FROM "users" ` +
+				`WHERE "email" = $1 AND "id" <> $2)`,
+			args: []any{"a@b.c", 7},
+		},
+		{
+			name: "except id column",
+			check: func(v *Validator) {
+				v.Unique("email", "users", "a@b.c", UniqueConfig{
+					ExceptID: "u1",
+					IDColumn: "uid",
+				})
+			},
+			query: `SELECT EXISTS(SELECT 1 FROM "users" ` +
+				`WHERE "email" = $1 AND "uid" <> $2)`,
+			args: []any{"a@b.c", "u1"},
+		},
+		{
+			name: "nil except id",
+			check: func(v *Validator) {
+				v.Unique("email", "users", "a@b.c", UniqueConfig{
+					ExceptID: noID,
+				})
+			},
+			query: `SELECT EXISTS(SELECT 1 FROM "users" ` +
+				`WHERE "email" = $1)`,
+			args: []any{"a@b.c"},
+		},
+		{
+			name: "where",
+			check: func(v *Validator) {
+				v.UniqueTogether(
+					"slug",
+					"products",
+					map[string]any{"store_id": 3, "slug": "tea"},
+					UniqueConfig{
+						Where: []squirrel.Sqlizer{
+							squirrel.Expr("deleted_at IS NULL"),
+						},
+					},
+				)
+			},
+			query: `SELECT EXISTS(SELECT 1 FROM "products" ` +
+				`WHERE "slug" = $1 AND "store_id" = $2 ` +
+				`AND deleted_at IS NULL)`,
+			args: []any{"tea", 3},
+		},
+		{
+			name: "nil value",
+			check: func(v *Validator) {
+				v.Unique("email", "users", noID, UniqueConfig{})
+			},
+		},
+		{
+			name: "no values",
+			check: func(v *Validator) {
+				v.UniqueTogether("slug", "products", nil, UniqueConfig{})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query string
+			var args []any
+			conn := &stubConn{
+				get: func(
+					_ context.Context,
+					dest any,
+					q string,
+					a ...any,
+				) error {
+					query, args = q, a
+					*dest.(*bool) = tt.exists
+					return nil
+				},
+			}
+			v, err := newTestValidator(
+				t,
+				&Config{Conn: conn},
+				"application/json",
+				`{}`,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(v)
+			if query != tt.query {
+				t.Fatalf("got %s\nwant %s", query, tt.query)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Fatalf("args %v, want %v", args, tt.args)
+			}
+			if v.Valid() == tt.failed {
+				t.Fatalf("Valid() = %t, errors %v", !tt.failed, v.GetErrorMap())
+			}
+			if tt.failed {
+				details := v.GetErrorDetails()
+				for _, d := range details {
+					if d[0].Code != CodeAlreadyExists {
+						t.Fatalf("details %+v", details)
+					}
+				}
+			}
+		})
+	}
+}
@@ -3,10 +3,9 @@ package validator
 import (
 	"context"
 	"errors"
-	"fmt"
 	"regexp"
 	"strconv"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/Masterminds/squirrel"
@@ -53,9 +52,55 @@ type Validator struct {
 	newFile  string
 	newImg   string
 	newThumb string
+	newFiles []string
 	oldFile  *string
 	oldImg   *string
 	oldThumb *string
+
+	// nonNullable holds the keys that may not be sent as json null
+	nonNullable map[string]struct{}
+	// noDuplicates holds the list keys whose elements must be distinct
+	noDuplicates map[string]struct{}
+	// details holds the code and params of causes added by CheckCode
+	details map[*js.ValidationError]ErrorDetail
+
+	sources    []Source
+	pathValues []string
+	headers    []string
+	cookies    []string
+
+	strict      bool
+	allowedKeys []string
+	// unknownKeys are the causes added by the last RejectUnknownKeys
+	unknownKeys []*js.ValidationError
+
+	maxBodySize        int64
+	maxDecodedBodySize int64
+
+	// locale is the tag negotiated when Config.T is nil
+	locale string
+
+	ctx          context.Context
+	queryTimeout time.Duration
+	// errs holds the database failures reported by Err
+	errs []error
+	// tables is the allow-list of Config.Tables, nil allows every table
+	tables map[string]struct{}
+	// memo holds the results of the queries of the request by cacheKey
+	memo         *queryMemo
+	cache        Cache
+	cacheTTL     time.Duration
+	cachedTables map[string]struct{}
+
+	// mu guards the failures and jobs, deferred checks run concurrently
+	mu          sync.Mutex
+	deferred    bool
+	parallelism int
+	jobs        []func(v *Validator)
+	dialect     Dialect
+	// dialectSet is true when the dialect comes from Config.Dialect, whose
+	// placeholders then override those of QB
+	dialectSet bool
 }
 
 // NewValidator is a helper which creates a new Validator instance with an
@@ -76,10 +121,58 @@ func NewValidator(c *Config) (*Validator, error) {
 			Message:                 "",
 			Causes:                  []*js.ValidationError{},
 		},
+		sources:    c.Sources,
+		pathValues: c.PathValues,
+		headers:    c.Headers,
+		cookies:    c.Cookies,
+
+		strict:      c.Strict,
+		allowedKeys: c.AllowedKeys,
+
+		maxBodySize:        c.MaxBodySize,
+		maxDecodedBodySize: c.MaxDecodedBodySize,
+
+		ctx:          c.Context,
+		queryTimeout: c.QueryTimeout,
+		memo:         &queryMemo{results: map[string]any{}},
+
+		deferred:    c.Deferred,
+		parallelism: c.Parallelism,
+		dialect:     c.Dialect,
+		dialectSet:  c.Dialect != "",
+	}
+	if v.dialect == "" {
+		v.dialect = inferDialect(c.QB)
+	} else if !v.dialect.supported() {
+		return nil, &UnsupportedDialectError{Dialect: v.dialect}
+	}
+	if c.Tables != nil {
+		v.tables = make(map[string]struct{}, len(c.Tables))
+		for _, table := range c.Tables {
+			v.tables[canonicalTable(table, v.dialect.quote())] = struct{}{}
+		}
+	}
+	if c.Cache != nil {
+		v.cache, v.cacheTTL = c.Cache, c.CacheTTL
+		v.cachedTables = make(map[string]struct{}, len(c.CachedTables))
+		for _, table := range c.CachedTables {
+			v.cachedTables[table] = struct{}{}
+		}
+	}
+	if v.ctx == nil && c.Request != nil {
+		v.ctx = c.Request.Context()
+	}
+	negotiated := v.T == nil
+	if negotiated {
+		v.locale, v.T = Negotiate(c.Request, c.DefaultLocale)
 	}
 	if err := v.Parse(c.Request); err != nil {
 		return nil, err
 	}
+	// the lang query parameter was read by Negotiate, not an unknown key
+	if negotiated && v.Data.Source(LangParam) == SourceQuery {
+		v.Data.use(LangParam)
+	}
 	return v, nil
 }
 
@@ -101,10 +194,16 @@ func (v *Validator) ValidateModelSchema(
 		v.AddModelSchemaError(tableName, errors.New("couldn't marshal input"))
 		return
 	}
-	if err := schema.Validate(data); err != nil {
+	// the schema validates decoded json values, not their encoding
+	var body any
+	if err := json.Unmarshal(data, &body); err != nil {
+		v.AddModelSchemaError(tableName, errors.New("couldn't marshal input"))
+		return
+	}
+	if err := schema.Validate(body); err != nil {
 		switch err := err.(type) { //nolint:errorlint // not of comparable err
 		case *js.ValidationError:
-			v.AddCause(err)
+			v.addSchemaError(err)
 			return
 		default:
 			v.AddModelSchemaError(tableName, err)
@@ -115,7 +214,7 @@ func (v *Validator) ValidateModelSchema(
 
 func (v *Validator) ValidatePropertySchema(key string) {
 	if !v.Data.KeyExists(key) {
-		v.Check(false, key, v.T.ValidateRequired())
+		v.CheckCode(false, key, CodeRequired, nil, v.T.ValidateRequired())
 		return
 	}
 	v.validateSchema(v.Data.GetBytes(key), key)
@@ -124,7 +223,7 @@ func (v *Validator) ValidatePropertySchema(key string) {
 func (v *Validator) ValidateInterfaceSchema(i any) {
 	data, err := json.Marshal(i)
 	if err != nil {
-		v.Check(false, "input", "couldn't unmarshal input")
+		v.CheckCode(false, "input", CodeJSON, nil, "couldn't unmarshal input")
 		return
 	}
 	v.validateSchema(data, "input")
@@ -133,15 +232,15 @@ func (v *Validator) ValidateInterfaceSchema(i any) {
 func (v *Validator) validateSchema(data []byte, key string) {
 	var body any
 	if err := json.Unmarshal(data, &body); err != nil {
-		v.Check(false, key, "couldn't unmarshal input")
+		v.CheckCode(false, key, CodeJSON, nil, "couldn't unmarshal input")
 		return
 	}
 	if err := v.Schema.Validate(body); err != nil {
 		switch err := err.(type) { //nolint:errorlint // not of comparable err
 		case *js.ValidationError:
-			v.AddCause(err)
+			v.addSchemaError(err)
 		default:
-			v.Check(false, key, err.Error())
+			v.CheckCode(false, key, CodeSchema, nil, err.Error())
 		}
 	}
 }
@@ -154,30 +253,36 @@ func (v *Validator) AddModelSchemaError(tableName string, err error) {
 		Message:                 err.Error(),
 		Causes:                  []*js.ValidationError{},
 	}
-	v.Error.Causes = append(v.Error.Causes, cause)
+	v.AddCause(cause)
 }
 
+// Valid reports whether no error was added and no database failure was met,
+// see Err. Deferred checks still queued are run first, see Validate, and in
+// strict mode the keys that weren't assigned so far are rejected.
 func (v *Validator) Valid() bool {
-	return v.Error.Message == "" && len(v.Error.Causes) == 0
+	v.runJobs(nil)
+	if v.strict {
+		v.RejectUnknownKeys()
+	}
+	return v.Error.Message == "" &&
+		len(v.Error.Causes) == 0 &&
+		len(v.errs) == 0
 }
 
 func (v *Validator) AddCause(cause *js.ValidationError) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
 	v.Error.Causes = append(v.Error.Causes, cause)
 }
 
 // Check the boolean condition, if !ok an error will be added to the causes
+// with CodeInvalid, see CheckCode.
 func (v *Validator) Check(ok bool, instanceLocation, message string) {
-	if !ok {
-		cause := &js.ValidationError{
-			InstanceLocation: instanceLocation,
-			Message:          message,
-			Causes:           []*js.ValidationError{},
-		}
-		v.Error.Causes = append(v.Error.Causes, cause)
-	}
+	v.CheckCode(ok, instanceLocation, CodeInvalid, nil, message)
 }
 
 func (v *Validator) GetErrorMap() Errors {
+	v.runJobs(nil)
 	errMap := make(Errors)
 	errMap = v.loopCauses(errMap, v.Error.Causes)
 	return errMap
@@ -189,9 +294,7 @@ func (v *Validator) loopCauses(
 ) Errors {
 	if len(causes) > 0 {
 		for _, cause := range causes {
-			key := cause.InstanceLocation
-			key = strings.Replace(key, "/", "", 1)
-			key = strings.ReplaceAll(key, "/", ".")
+			key := errorKey(cause.InstanceLocation)
 			message := cause.Message
 			if len(cause.Causes) != 0 {
 				errMap = v.loopCauses(errMap, cause.Causes)
@@ -207,61 +310,197 @@ func (v *Validator) loopCauses(
 	return errMap
 }
 
+// ForbidNull marks keys that may not be cleared by sending a json null, the
+// Assign helpers report them as required instead of returning nil.
+//
+//	v.ForbidNull("name")
+//	m.Name = v.AssignString("name", m.Name, 3, 50)
+func (v *Validator) ForbidNull(keys ...string) {
+	if v.nonNullable == nil {
+		v.nonNullable = make(map[string]struct{}, len(keys))
+	}
+	for _, key := range keys {
+		v.nonNullable[normalizeKey(key)] = struct{}{}
+	}
+}
+
+// assignNull returns the value a nullable property takes when its key was
+// sent as json null: nil, unless the key was marked with ForbidNull.
+func assignNull[T any](v *Validator, key string, property *T) *T {
+	if _, forbidden := v.nonNullable[normalizeKey(key)]; forbidden {
+		v.CheckCode(false, key, CodeRequired, nil, v.T.ValidateRequired())
+		return property
+	}
+	return nil
+}
+
+// AssignBool assigns the value of key to property, nullable bools must be
+// assigned back:
+//
+//	m.IsActive = v.AssignBool("is_active", m.IsActive)
 func (v *Validator) AssignBool(
 	key string,
 	property *bool,
 	allowedScopes ...string,
-) {
+) *bool {
 	if v.Data.KeyExists(key) {
 		v.Permit(key, allowedScopes)
+		if v.Data.IsNull(key) {
+			return assignNull(v, key, property)
+		}
 		if property == nil {
 			property = new(bool)
 		}
 		*property = v.Data.GetBool(key)
 	}
+	return property
 }
 
 func (v *Validator) AssignInt(
 	key string,
 	property *int,
 	allowedScopes ...string,
-) {
+) *int {
 	if v.Data.KeyExists(key) {
 		v.Permit(key, allowedScopes)
+		if v.Data.IsNull(key) {
+			return assignNull(v, key, property)
+		}
 		if property == nil {
 			property = new(int)
 		}
 		if value, err := strconv.ParseInt(v.Data.Get(key), 10, 0); err != nil {
-			v.Check(false, key, v.T.ValidateInt())
+			v.CheckCode(false, key, CodeInt, nil, v.T.ValidateInt())
 		} else {
 			*property = int(value)
 		}
 	}
+	return property
+}
+
+// AssignInt64 assigns the integer literal of key to property, json numbers are
+// kept as sent so values beyond float64 precision are not rounded.
+func (v *Validator) AssignInt64(
+	key string,
+	property *int64,
+	allowedScopes ...string,
+) *int64 {
+	if v.Data.KeyExists(key) {
+		v.Permit(key, allowedScopes)
+		if v.Data.IsNull(key) {
+			return assignNull(v, key, property)
+		}
+		if property == nil {
+			property = new(int64)
+		}
+		if value, err := strconv.ParseInt(v.Data.Get(key), 10, 64); err != nil {
+			v.CheckCode(false, key, CodeInt, nil, v.T.ValidateInt())
+		} else {
+			*property = value
+		}
+	}
+	return property
+}
+
+// AssignUint assigns the unsigned integer literal of key to property,
+// negative values are reported as not greater than or equal to zero.
+func (v *Validator) AssignUint(
+	key string,
+	property *uint,
+	allowedScopes ...string,
+) *uint {
+	if v.Data.KeyExists(key) {
+		v.Permit(key, allowedScopes)
+		if v.Data.IsNull(key) {
+			return assignNull(v, key, property)
+		}
+		if property == nil {
+			property = new(uint)
+		}
+		if value, ok := v.parseUint(key, 0); ok {
+			*property = uint(value)
+		}
+	}
+	return property
+}
+
+// AssignUint64 assigns the unsigned integer literal of key to property.
+func (v *Validator) AssignUint64(
+	key string,
+	property *uint64,
+	allowedScopes ...string,
+) *uint64 {
+	if v.Data.KeyExists(key) {
+		v.Permit(key, allowedScopes)
+		if v.Data.IsNull(key) {
+			return assignNull(v, key, property)
+		}
+		if property == nil {
+			property = new(uint64)
+		}
+		if value, ok := v.parseUint(key, 64); ok {
+			*property = value
+		}
+	}
+	return property
+}
+
+func (v *Validator) parseUint(key string, bitSize int) (uint64, bool) {
+	val := v.Data.Get(key)
+	value, err := strconv.ParseUint(val, 10, bitSize)
+	if err != nil {
+		if _, err := strconv.ParseInt(val, 10, 64); err == nil {
+			v.CheckCode(
+				false,
+				key,
+				CodeMin,
+				Params{"min": 0},
+				v.T.ValidateMustBeGteZero(),
+			)
+		} else {
+			v.CheckCode(false, key, CodeInt, nil, v.T.ValidateInt())
+		}
+		return 0, false
+	}
+	return value, true
 }
 
 func (v *Validator) AssignFloat(
 	key string,
 	property *float64,
 	allowedScopes ...string,
-) {
+) *float64 {
 	if v.Data.KeyExists(key) {
 		v.Permit(key, allowedScopes)
+		if v.Data.IsNull(key) {
+			return assignNull(v, key, property)
+		}
 		if property == nil {
 			property = new(float64)
 		}
 		if value, err := strconv.ParseFloat(v.Data.Get(key), 64); err != nil {
-			v.Check(false, key, v.T.ValidateRequiredFloat())
+			v.CheckCode(false, key, CodeFloat, nil, v.T.ValidateRequiredFloat())
 		} else {
 			*property = value
 		}
 	}
+	return property
 }
 
 func (v *Validator) AssignDate(key string, property *string) *string {
 	if v.Data.KeyExists(key) {
+		if v.Data.IsNull(key) {
+			return assignNull(v, key, property)
+		}
 		if val := v.Data.Get(key); val != "" {
 			if t, err := time.Parse(time.DateOnly, val); err != nil {
-				v.Check(false, key, err.Error())
+				v.CheckCode(
+					false,
+					key,
+					CodeDate,
+					Params{"layout": time.DateOnly},
+					err.Error(),
+				)
 			} else {
 				s := t.Format("2006-01-02")
 				if property == nil {
@@ -278,40 +517,64 @@ func (v *Validator) AssignTimestamp(
 	key string,
 	property *time.Time,
 	allowedScopes ...string,
-) {
+) *time.Time {
 	if v.Data.KeyExists(key) {
+		if v.Data.IsNull(key) {
+			v.Permit(key, allowedScopes)
+			return assignNull(v, key, property)
+		}
 		if val := v.Data.Get(key); val != "" {
 			v.Permit(key, allowedScopes)
 			t, err := time.Parse(time.RFC3339, val)
 			if err != nil {
-				v.Check(false, key, err.Error())
-				return
+				v.CheckCode(
+					false,
+					key,
+					CodeDate,
+					Params{"layout": time.RFC3339},
+					err.Error(),
+				)
+				return property
 			}
-			if property != nil {
-				*property = t
+			if property == nil {
+				property = new(time.Time)
 			}
+			*property = t
 		}
 	}
+	return property
 }
 
 func (v *Validator) AssignClock(
 	key string,
 	property *time.Time,
 	allowedScopes ...string,
-) {
+) *time.Time {
 	if v.Data.KeyExists(key) {
+		if v.Data.IsNull(key) {
+			v.Permit(key, allowedScopes)
+			return assignNull(v, key, property)
+		}
 		if val := v.Data.Get(key); val != "" {
 			v.Permit(key, allowedScopes)
 			t, err := time.Parse("15:04", val)
 			if err != nil {
-				v.Check(false, key, err.Error())
-				return
+				v.CheckCode(
+					false,
+					key,
+					CodeDate,
+					Params{"layout": "15:04"},
+					err.Error(),
+				)
+				return property
 			}
-			if property != nil {
-				*property = t
+			if property == nil {
+				property = new(time.Time)
 			}
+			*property = t
 		}
 	}
+	return property
 }
 
 func (v *Validator) AssignUUID(
@@ -320,6 +583,14 @@ func (v *Validator) AssignUUID(
 	required bool,
 	allowedScopes ...string,
 ) *uuid.UUID {
+	if v.Data.IsNull(key) {
+		v.Permit(key, allowedScopes)
+		if required {
+			v.CheckCode(false, key, CodeRequired, nil, v.T.ValidateRequired())
+			return property
+		}
+		return assignNull(v, key, property)
+	}
 	keyUUID := v.Data.GetUUID(key)
 	if keyUUID != nil {
 		v.Permit(key, allowedScopes)
@@ -341,38 +612,39 @@ func (v *Validator) UnmarshalInto(
 	if v.Data.KeyExists(key) {
 		v.Permit(key, allowedScopes)
 		if err := v.Data.GetAndUnmarshalJSON(key, property); err != nil {
-			v.Check(false, key, err.Error())
+			v.CheckCode(false, key, CodeJSON, nil, err.Error())
 		}
 	}
 }
 
 // Exists check if an id exists in a table row.
 //
-// using the following query:
+// using the following query, tableName may be schema qualified and must be
+// listed in Config.Tables when set:
 //
-//	SELECT EXISTS(SELECT 1 FROM tableName WHERE id=$1)
+//	SELECT EXISTS(SELECT 1 FROM "tableName" WHERE "tableField" = $1)
 func (v *Validator) Exists(
 	id any,
 	key, tableField, tableName string,
 	required bool,
 ) {
-	var exists bool
-	query := fmt.Sprintf(
-		`SELECT EXISTS(SELECT 1 FROM %s WHERE %s=$1)`,
-		tableName,
-		tableField,
-	)
-	if err := v.Conn.GetContext(
-		context.Background(),
-		&exists,
-		query,
-		id,
-	); err != nil {
-		exists = false
-	}
-	if required {
-		v.Check(exists, key, v.T.ValidateExistsInDB())
+	eq, err := v.columnEq(tableField, snapshot(id))
+	if err != nil {
+		v.queryFailed(key, err)
+		return
 	}
+	v.run(func(v *Validator) {
+		exists, ok := v.existsWhere(key, tableName, true, eq)
+		if ok && required {
+			v.CheckCode(
+				exists,
+				key,
+				CodeNotExists,
+				nil,
+				v.T.ValidateExistsInDB(),
+			)
+		}
+	})
 }
 
 // IDExistsInDB checks if the field value of an int id exists in database
@@ -382,7 +654,7 @@ func (v *Validator) IDExistsInDB(
 	required bool,
 ) {
 	if id == nil && required {
-		v.Check(false, key, v.T.ValidateRequired())
+		v.CheckCode(false, key, CodeRequired, nil, v.T.ValidateRequired())
 		return
 	}
 	// only allows the check if the value in the model is not equal to the input
@@ -397,39 +669,85 @@ func (v *Validator) UUIDExistsInDB(
 ) {
 	if id != nil {
 		if required {
-			v.Check(false, key, v.T.ValidateRequired())
+			v.CheckCode(false, key, CodeRequired, nil, v.T.ValidateRequired())
 		}
 	}
 	v.Exists(id, key, tableField, tableName, required)
 }
 
 // UserIDHasRole checks if the user id has role name associated with it
+//
+// using the following query:
+//
+//	SELECT EXISTS(
+//		SELECT 1 FROM "users"
+//		LEFT JOIN "user_roles" ON "users"."id" = "user_roles"."user_id"
+//		LEFT JOIN "roles" ON "roles"."id" = "user_roles"."role_id"
+//		WHERE "roles"."name" = $1 AND "users"."id" = $2
+//	)
 func (v *Validator) UserIDHasRole(
 	fieldName string,
 	userID *uuid.UUID,
 	roleName string,
 ) {
-	var exists bool
-
-	query := `
-		SELECT EXISTS(
-		    SELECT 1
-		    FROM users
-                LEFT JOIN user_roles on users.id = user_roles."user_id"
-                LEFT JOIN roles on roles.id = user_roles."role_id"
-		    WHERE roles.name = $2
-                AND users.id = $1
-		) AS exists;
-	`
-
-	if err := v.Conn.GetContext(
-		context.Background(),
-		&exists,
-		query,
-		userID,
-		roleName,
-	); err != nil {
-		exists = false
-	}
-	v.Check(exists, fieldName, v.T.ValidateMustHaveRole(roleName))
+	query, args, err := v.userRoleQuery(snapshot(userID), roleName)
+	if err != nil {
+		v.queryFailed(fieldName, err)
+		return
+	}
+	v.run(func(v *Validator) {
+		var exists bool
+		if err := v.get("", &exists, query, args...); err != nil {
+			v.queryFailed(fieldName, err)
+			return
+		}
+		v.CheckCode(
+			exists,
+			fieldName,
+			CodeMustHaveRole,
+			Params{"role": roleName},
+			v.T.ValidateMustHaveRole(roleName),
+		)
+	})
+}
+
+func (v *Validator) userRoleQuery(
+	userID any,
+	roleName string,
+) (string, []any, error) {
+	names := map[string]string{}
+	for _, name := range []string{"users", "user_roles", "roles"} {
+		quoted, err := v.table(name)
+		if err != nil {
+			return "", nil, err
+		}
+		names[name] = quoted
+	}
+	for _, name := range []string{
+		"users.id",
+		"user_roles.user_id",
+		"user_roles.role_id",
+		"roles.id",
+		"roles.name",
+	} {
+		quoted, err := v.quote(name, 2)
+		if err != nil {
+			return "", nil, err
+		}
+		names[name] = quoted
+	}
+	qb := v.builder()
+	sub := qb.Select("1").
+		From(names["users"]).
+		LeftJoin(
+			names["user_roles"] + " ON " +
+				names["users.id"] + " = " + names["user_roles.user_id"],
+		).
+		LeftJoin(
+			names["roles"] + " ON " +
+				names["roles.id"] + " = " + names["user_roles.role_id"],
+		).
+		Where(squirrel.Expr(names["roles.name"]+" = ?", roleName)).
+		Where(squirrel.Expr(names["users.id"]+" = ?", userID))
+	return qb.Select().Column(squirrel.Expr("EXISTS(?)", sub)).ToSql()
 }
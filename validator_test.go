@@ -0,0 +1,108 @@
+package validator
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestAssignNumbers(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		assign func(v *Validator) string
+		want   string
+		code   string
+	}{
+		{
+			name: "uint64 beyond int64",
+			body: `{"n":12345678901234567890}`,
+			assign: func(v *Validator) string {
+				return strconv.FormatUint(*v.AssignUint64("n", nil), 10)
+			},
+			want: "12345678901234567890",
+		},
+		{
+			name: "int64 beyond float64 precision",
+			body: `{"n":9007199254740993}`,
+			assign: func(v *Validator) string {
+				return strconv.FormatInt(*v.AssignInt64("n", nil), 10)
+			},
+			want: "9007199254740993",
+		},
+		{
+			name: "negative int64",
+			body: `{"n":-9223372036854775807}`,
+			assign: func(v *Validator) string {
+				return strconv.FormatInt(*v.AssignInt64("n", nil), 10)
+			},
+			want: "-9223372036854775807",
+		},
+		{
+			name: "negative uint",
+			body: `{"n":-1}`,
+			assign: func(v *Validator) string {
+				return strconv.FormatUint(uint64(*v.AssignUint("n", nil)), 10)
+			},
+			want: "0",
+			code: CodeMin,
+		},
+		{
+			name: "fractional uint",
+			body: `{"n":1.5}`,
+			assign: func(v *Validator) string {
+				return strconv.FormatUint(uint64(*v.AssignUint("n", nil)), 10)
+			},
+			want: "0",
+			code: CodeInt,
+		},
+		{
+			name: "uint64 overflow",
+			body: `{"n":18446744073709551616}`,
+			assign: func(v *Validator) string {
+				return strconv.FormatUint(*v.AssignUint64("n", nil), 10)
+			},
+			want: "0",
+			code: CodeInt,
+		},
+		{
+			name: "nested literal",
+			body: `{"price":{"amount":1.50}}`,
+			assign: func(v *Validator) string {
+				return v.Data.Get("price.amount")
+			},
+			want: "1.50",
+		},
+		{
+			name: "exponent",
+			body: `{"n":1e3}`,
+			assign: func(v *Validator) string {
+				return v.Data.Get("n")
+			},
+			want: "1e3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := newTestValidator(t, nil, "application/json", tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := tt.assign(v); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+			details := v.GetErrorDetails()["n"]
+			if tt.code == "" {
+				if !v.Valid() {
+					t.Fatalf("errors %v", v.GetErrorMap())
+				}
+				return
+			}
+			if len(details) != 1 || details[0].Code != tt.code {
+				t.Fatalf("details %+v, want %s", details, tt.code)
+			}
+			if tt.code == CodeMin && details[0].Params["min"] != 0 {
+				t.Fatalf("params %v, want min 0", details[0].Params)
+			}
+		})
+	}
+}